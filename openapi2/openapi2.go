@@ -8,7 +8,7 @@ import (
 
 // T is the root of an OpenAPI v2 document
 type T struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Swagger             string                         `json:"swagger" yaml:"swagger"` // required
 	Info                openapi3.Info                  `json:"info" yaml:"info"`       // required
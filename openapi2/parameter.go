@@ -24,7 +24,7 @@ func (ps Parameters) Less(i, j int) bool {
 }
 
 type Parameter struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 
@@ -9,7 +9,7 @@ import (
 )
 
 type PathItem struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 
@@ -7,7 +7,7 @@ import (
 )
 
 type Response struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 
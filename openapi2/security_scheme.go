@@ -9,7 +9,7 @@ import (
 type SecurityRequirements []map[string][]string
 
 type SecurityScheme struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 
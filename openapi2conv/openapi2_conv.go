@@ -124,7 +124,9 @@ func ToV3PathItem(doc2 *openapi2.T, components *openapi3.Components, pathItem *o
 		if err != nil {
 			return nil, err
 		}
-		doc3.SetOperation(method, doc3Operation)
+		if err := doc3.SetOperation(method, doc3Operation); err != nil {
+			return nil, err
+		}
 	}
 	for _, parameter := range pathItem.Parameters {
 		v3Parameter, v3RequestBody, v3Schema, err := ToV3Parameter(components, parameter, consumes)
@@ -260,7 +262,7 @@ func ToV3Parameter(components *openapi3.Components, parameter *openapi2.Paramete
 		}
 		schemaRef := &openapi3.SchemaRef{Value: &openapi3.Schema{
 			Description:     parameter.Description,
-			Type:            typ,
+			Type:            toV3SchemaTypes(typ),
 			Extensions:      stripNonExtensions(parameter.Extensions),
 			Format:          format,
 			Enum:            parameter.Enum,
@@ -301,7 +303,7 @@ func ToV3Parameter(components *openapi3.Components, parameter *openapi2.Paramete
 			Required:    required,
 			Extensions:  stripNonExtensions(parameter.Extensions),
 			Schema: ToV3SchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
-				Type:            parameter.Type,
+				Type:            toV3SchemaTypes(parameter.Type),
 				Format:          parameter.Format,
 				Enum:            parameter.Enum,
 				Min:             parameter.Minimum,
@@ -340,7 +342,7 @@ func formDataBody(bodies map[string]*openapi3.SchemaRef, reqs map[string]bool, c
 		}
 	}
 	schema := &openapi3.Schema{
-		Type:       "object",
+		Type:       openapi3.SchemaTypes{"object"},
 		Properties: ToV3Schemas(bodies),
 		Required:   requireds,
 	}
@@ -763,7 +765,7 @@ func FromV3SchemaRef(schema *openapi3.SchemaRef, components *openapi3.Components
 	}
 
 	if schema.Value != nil {
-		if schema.Value.Type == "string" && schema.Value.Format == "binary" {
+		if schema.Value.Type.Is("string") && schema.Value.Format == "binary" {
 			paramType := "file"
 			required := false
 
@@ -879,7 +881,7 @@ func FromV3RequestBodyFormData(mediaType *openapi3.MediaType) openapi2.Parameter
 			continue
 		}
 		val := schemaRef.Value
-		typ := val.Type
+		typ := fromV3SchemaTypes(val.Type)
 		if val.Format == "binary" {
 			typ = "file"
 		}
@@ -1019,7 +1021,7 @@ func FromV3Parameter(ref *openapi3.ParameterRef, components *openapi3.Components
 			return result, nil
 		}
 		schema := schemaRef.Value
-		result.Type = schema.Type
+		result.Type = fromV3SchemaTypes(schema.Type)
 		result.Format = schema.Format
 		result.Enum = schema.Enum
 		result.Minimum = schema.Min
@@ -1171,6 +1173,24 @@ var attemptedBodyParameterNames = []string{
 	"requestBody",
 }
 
+// toV3SchemaTypes returns the schema types of an OpenAPI 2 parameter type
+func toV3SchemaTypes(typ string) openapi3.SchemaTypes {
+	if typ == "" {
+		return nil
+	}
+	return openapi3.SchemaTypes{typ}
+}
+
+// fromV3SchemaTypes returns the OpenAPI 2 parameter type of schema types: the first one that is not "null"
+func fromV3SchemaTypes(types openapi3.SchemaTypes) string {
+	for _, typ := range types {
+		if typ != openapi3.TypeNull {
+			return typ
+		}
+	}
+	return ""
+}
+
 // stripNonExtensions removes invalid extensions: those not prefixed by "x-" and returns them
 func stripNonExtensions(extensions map[string]interface{}) map[string]interface{} {
 	for extName := range extensions {
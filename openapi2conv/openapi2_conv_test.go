@@ -383,6 +383,36 @@ const exampleV2 = `
 	"schemes": [
 		"https"
 	],
+	"securityDefinitions": {
+		"default_security_0": {
+			"type": "oauth2",
+			"flow": "implicit",
+			"authorizationUrl": "https://test.example.com/oauth/authorize",
+			"scopes": {
+				"scope0": "scope 0",
+				"scope1": "scope 1"
+			}
+		},
+		"default_security_1": {
+			"type": "apiKey",
+			"in": "header",
+			"name": "X-Default-Key"
+		},
+		"get_security_0": {
+			"type": "oauth2",
+			"flow": "implicit",
+			"authorizationUrl": "https://test.example.com/oauth/authorize",
+			"scopes": {
+				"scope0": "scope 0",
+				"scope1": "scope 1"
+			}
+		},
+		"get_security_1": {
+			"type": "apiKey",
+			"in": "header",
+			"name": "X-Get-Key"
+		}
+	},
 	"security": [
 		{
 			"default_security_0": [
@@ -495,6 +525,42 @@ const exampleV3 = `
 				],
 				"type": "string"
 			}
+		},
+		"securitySchemes": {
+			"default_security_0": {
+				"type": "oauth2",
+				"flows": {
+					"implicit": {
+						"authorizationUrl": "https://test.example.com/oauth/authorize",
+						"scopes": {
+							"scope0": "scope 0",
+							"scope1": "scope 1"
+						}
+					}
+				}
+			},
+			"default_security_1": {
+				"type": "apiKey",
+				"in": "header",
+				"name": "X-Default-Key"
+			},
+			"get_security_0": {
+				"type": "oauth2",
+				"flows": {
+					"implicit": {
+						"authorizationUrl": "https://test.example.com/oauth/authorize",
+						"scopes": {
+							"scope0": "scope 0",
+							"scope1": "scope 1"
+						}
+					}
+				}
+			},
+			"get_security_1": {
+				"type": "apiKey",
+				"in": "header",
+				"name": "X-Get-Key"
+			}
 		}
 	},
 	"externalDocs": {
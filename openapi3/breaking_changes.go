@@ -0,0 +1,283 @@
+package openapi3
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kinds of BreakingChange
+const (
+	BreakingChangeRemovedPath            = "removedPath"
+	BreakingChangeRemovedOperation       = "removedOperation"
+	BreakingChangeAddedRequiredParameter = "addedRequiredParameter"
+	BreakingChangeAddedRequiredProperty  = "addedRequiredProperty"
+	BreakingChangeNarrowedType           = "narrowedType"
+	BreakingChangeRemovedResponseCode    = "removedResponseCode"
+	BreakingChangeRemovedSecurityScheme  = "removedSecurityScheme"
+)
+
+// BreakingChange describes a change between two versions of a document
+// that may break clients of the older version.
+type BreakingChange struct {
+	// Kind is one of the BreakingChange* constants.
+	Kind string
+	// Location is the JSON Pointer to the changed value in the older document.
+	Location string
+	OldValue string
+	NewValue string
+}
+
+// CompatibilityOption allows the modification of how documents are compared.
+type CompatibilityOption func(options *compatibilityOptions)
+
+type compatibilityOptions struct {
+	ignoredKinds map[string]struct{}
+	ignoredPaths map[string]struct{}
+}
+
+// IgnoreBreakingChangeKinds makes IsBackwardCompatibleWith not report changes of the given kinds.
+func IgnoreBreakingChangeKinds(kinds ...string) CompatibilityOption {
+	return func(options *compatibilityOptions) {
+		for _, kind := range kinds {
+			options.ignoredKinds[kind] = struct{}{}
+		}
+	}
+}
+
+// IgnoreBreakingChangePaths makes IsBackwardCompatibleWith not report changes of the given paths (e.g. "/pets/{petId}").
+func IgnoreBreakingChangePaths(paths ...string) CompatibilityOption {
+	return func(options *compatibilityOptions) {
+		for _, path := range paths {
+			options.ignoredPaths[path] = struct{}{}
+		}
+	}
+}
+
+type compatibilityChecker struct {
+	options *compatibilityOptions
+	changes []BreakingChange
+	visited map[[2]*Schema]struct{}
+}
+
+// IsBackwardCompatibleWith reports whether clients of the old document can use doc,
+// listing the changes that break them.
+// Both documents are expected to have their references resolved.
+func (doc *T) IsBackwardCompatibleWith(old *T, opts ...CompatibilityOption) (bool, []BreakingChange) {
+	c := &compatibilityChecker{
+		options: &compatibilityOptions{
+			ignoredKinds: make(map[string]struct{}),
+			ignoredPaths: make(map[string]struct{}),
+		},
+		visited: make(map[[2]*Schema]struct{}),
+	}
+	for _, opt := range opts {
+		opt(c.options)
+	}
+
+	c.comparePaths(old.Paths, doc.Paths)
+
+	var oldSchemes, newSchemes SecuritySchemes
+	if old.Components != nil {
+		oldSchemes = old.Components.SecuritySchemes
+	}
+	if doc.Components != nil {
+		newSchemes = doc.Components.SecuritySchemes
+	}
+	names := make([]string, 0, len(oldSchemes))
+	for name := range oldSchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := newSchemes[name]; !ok {
+			c.report(BreakingChangeRemovedSecurityScheme, "/components/securitySchemes/"+escapeJSONPointerToken(name), name, "")
+		}
+	}
+
+	return len(c.changes) == 0, c.changes
+}
+
+func (c *compatibilityChecker) report(kind, location, oldValue, newValue string) {
+	if _, ok := c.options.ignoredKinds[kind]; ok {
+		return
+	}
+	c.changes = append(c.changes, BreakingChange{
+		Kind:     kind,
+		Location: location,
+		OldValue: oldValue,
+		NewValue: newValue,
+	})
+}
+
+func (c *compatibilityChecker) comparePaths(oldPaths, newPaths Paths) {
+	paths := make([]string, 0, len(oldPaths))
+	for path := range oldPaths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if _, ok := c.options.ignoredPaths[path]; ok {
+			continue
+		}
+		location := "/paths/" + escapeJSONPointerToken(path)
+		oldPathItem, newPathItem := oldPaths[path], newPaths[path]
+		if newPathItem == nil {
+			c.report(BreakingChangeRemovedPath, location, path, "")
+			continue
+		}
+		if oldPathItem == nil {
+			continue
+		}
+
+		oldOperations, newOperations := oldPathItem.Operations(), newPathItem.Operations()
+		methods := make([]string, 0, len(oldOperations))
+		for method := range oldOperations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operationLocation := location + "/" + strings.ToLower(method)
+			newOperation := newOperations[method]
+			if newOperation == nil {
+				c.report(BreakingChangeRemovedOperation, operationLocation, method, "")
+				continue
+			}
+			c.compareOperations(operationLocation, oldPathItem, oldOperations[method], newPathItem, newOperation)
+		}
+	}
+}
+
+func (c *compatibilityChecker) compareOperations(location string, oldPathItem *PathItem, oldOperation *Operation, newPathItem *PathItem, newOperation *Operation) {
+	oldParameters := effectiveParameters(oldPathItem, oldOperation)
+	newParameters := effectiveParameters(newPathItem, newOperation)
+	for _, parameterRef := range newParameters {
+		newParameter := parameterRef.Value
+		oldParameter := oldParameters.GetByInAndName(newParameter.In, newParameter.Name)
+		if newParameter.Required && (oldParameter == nil || !oldParameter.Required) {
+			c.report(BreakingChangeAddedRequiredParameter, location+"/parameters", "", newParameter.In+":"+newParameter.Name)
+		}
+		if oldParameter != nil && oldParameter.Schema != nil && newParameter.Schema != nil {
+			c.compareSchemas(location+"/parameters/"+escapeJSONPointerToken(newParameter.Name), oldParameter.Schema.Value, newParameter.Schema.Value, true)
+		}
+	}
+
+	if oldBody, newBody := oldOperation.RequestBody, newOperation.RequestBody; oldBody != nil && newBody != nil && oldBody.Value != nil && newBody.Value != nil {
+		c.compareContents(location+"/requestBody/content", oldBody.Value.Content, newBody.Value.Content, true)
+	}
+
+	codes := make([]string, 0, len(oldOperation.Responses))
+	for code := range oldOperation.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		responseLocation := location + "/responses/" + code
+		newResponse := newOperation.Responses[code]
+		if newResponse == nil {
+			c.report(BreakingChangeRemovedResponseCode, responseLocation, code, "")
+			continue
+		}
+		if oldResponse := oldOperation.Responses[code]; oldResponse != nil && oldResponse.Value != nil && newResponse.Value != nil {
+			c.compareContents(responseLocation+"/content", oldResponse.Value.Content, newResponse.Value.Content, false)
+		}
+	}
+}
+
+func (c *compatibilityChecker) compareContents(location string, oldContent, newContent Content, asRequest bool) {
+	mediaTypes := make([]string, 0, len(oldContent))
+	for mediaType := range oldContent {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		oldMediaType, newMediaType := oldContent[mediaType], newContent[mediaType]
+		if oldMediaType == nil || newMediaType == nil || oldMediaType.Schema == nil || newMediaType.Schema == nil {
+			continue
+		}
+		c.compareSchemas(location+"/"+escapeJSONPointerToken(mediaType)+"/schema", oldMediaType.Schema.Value, newMediaType.Schema.Value, asRequest)
+	}
+}
+
+// compareSchemas reports the changes that reject values accepted by oldSchema (asRequest)
+// or that produce values not described by oldSchema.
+func (c *compatibilityChecker) compareSchemas(location string, oldSchema, newSchema *Schema, asRequest bool) {
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+	key := [2]*Schema{oldSchema, newSchema}
+	if _, ok := c.visited[key]; ok {
+		// recursive schema
+		return
+	}
+	c.visited[key] = struct{}{}
+	defer delete(c.visited, key)
+
+	if oldType, newType := oldSchema.Type, newSchema.Type; len(newType) != 0 {
+		narrowed := len(oldType) == 0
+		for _, typ := range oldType {
+			// Clients sending integers still match a number schema
+			widened := asRequest && typ == TypeInteger && newType.Includes(TypeNumber)
+			if !widened && !newType.Includes(typ) {
+				narrowed = true
+			}
+		}
+		if narrowed {
+			c.report(BreakingChangeNarrowedType, location+"/type", oldType.String(), newType.String())
+		}
+	}
+
+	if asRequest {
+		oldRequired := make(map[string]struct{}, len(oldSchema.Required))
+		for _, name := range oldSchema.Required {
+			oldRequired[name] = struct{}{}
+		}
+		for _, name := range newSchema.Required {
+			if _, ok := oldRequired[name]; !ok {
+				c.report(BreakingChangeAddedRequiredProperty, location+"/required", "", name)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(oldSchema.Properties))
+	for name := range oldSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		oldProperty, newProperty := oldSchema.Properties[name], newSchema.Properties[name]
+		if oldProperty == nil || newProperty == nil {
+			continue
+		}
+		c.compareSchemas(location+"/properties/"+escapeJSONPointerToken(name), oldProperty.Value, newProperty.Value, asRequest)
+	}
+
+	if oldItems, newItems := oldSchema.Items, newSchema.Items; oldItems != nil && newItems != nil {
+		c.compareSchemas(location+"/items", oldItems.Value, newItems.Value, asRequest)
+	}
+}
+
+// effectiveParameters returns the parameters of an operation, including the ones of its path item.
+func effectiveParameters(pathItem *PathItem, operation *Operation) Parameters {
+	parameters := make(Parameters, 0, len(pathItem.Parameters)+len(operation.Parameters))
+	for _, parameterRef := range operation.Parameters {
+		if parameterRef != nil && parameterRef.Value != nil {
+			parameters = append(parameters, parameterRef)
+		}
+	}
+	for _, parameterRef := range pathItem.Parameters {
+		if parameterRef != nil && parameterRef.Value != nil &&
+			operation.Parameters.GetByInAndName(parameterRef.Value.In, parameterRef.Value.Name) == nil {
+			parameters = append(parameters, parameterRef)
+		}
+	}
+	sort.SliceStable(parameters, func(i, j int) bool {
+		a, b := parameters[i].Value, parameters[j].Value
+		return a.In+":"+a.Name < b.In+":"+b.Name
+	})
+	return parameters
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
@@ -0,0 +1,150 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBackwardCompatibleWith(t *testing.T) {
+	oldSpec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+      - name: limit
+        in: query
+        schema:
+          type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        '404':
+          description: Not found
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+  /pets/{petId}:
+    get:
+      parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+        parent:
+          $ref: '#/components/schemas/Pet'
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: Api-Key
+      in: header
+`
+	newSpec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+      - name: limit
+        in: query
+        required: true
+        schema:
+          type: number
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, tag]
+      properties:
+        name:
+          type: string
+        age:
+          type: string
+        tag:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Pet'
+`
+	loader := NewLoader()
+	oldDoc, err := loader.LoadFromData([]byte(oldSpec))
+	require.NoError(t, err)
+	newDoc, err := loader.LoadFromData([]byte(newSpec))
+	require.NoError(t, err)
+
+	ok, changes := oldDoc.IsBackwardCompatibleWith(oldDoc)
+	require.True(t, ok)
+	require.Empty(t, changes)
+
+	ok, changes = newDoc.IsBackwardCompatibleWith(oldDoc)
+	require.False(t, ok)
+	require.Equal(t, []BreakingChange{
+		{Kind: BreakingChangeAddedRequiredParameter, Location: "/paths/~1pets/get/parameters", NewValue: "query:limit"},
+		{Kind: BreakingChangeNarrowedType, Location: "/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/age/type", OldValue: "integer", NewValue: "string"},
+		{Kind: BreakingChangeRemovedResponseCode, Location: "/paths/~1pets/get/responses/404", OldValue: "404"},
+		{Kind: BreakingChangeAddedRequiredProperty, Location: "/paths/~1pets/post/requestBody/content/application~1json/schema/required", NewValue: "tag"},
+		{Kind: BreakingChangeNarrowedType, Location: "/paths/~1pets/post/requestBody/content/application~1json/schema/properties/age/type", OldValue: "integer", NewValue: "string"},
+		{Kind: BreakingChangeRemovedPath, Location: "/paths/~1pets~1{petId}", OldValue: "/pets/{petId}"},
+		{Kind: BreakingChangeRemovedSecurityScheme, Location: "/components/securitySchemes/apiKey", OldValue: "apiKey"},
+	}, changes)
+
+	ok, changes = newDoc.IsBackwardCompatibleWith(oldDoc,
+		IgnoreBreakingChangeKinds(BreakingChangeNarrowedType, BreakingChangeAddedRequiredProperty, BreakingChangeRemovedSecurityScheme),
+		IgnoreBreakingChangePaths("/pets/{petId}"),
+	)
+	require.False(t, ok)
+	require.Equal(t, []BreakingChange{
+		{Kind: BreakingChangeAddedRequiredParameter, Location: "/paths/~1pets/get/parameters", NewValue: "query:limit"},
+		{Kind: BreakingChangeRemovedResponseCode, Location: "/paths/~1pets/get/responses/404", OldValue: "404"},
+	}, changes)
+}
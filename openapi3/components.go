@@ -10,7 +10,7 @@ import (
 // Components is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#components-object
 type Components struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Schemas         Schemas         `json:"schemas,omitempty" yaml:"schemas,omitempty"`
 	Parameters      ParametersMap   `json:"parameters,omitempty" yaml:"parameters,omitempty"`
@@ -87,6 +87,9 @@ func (components *Components) UnmarshalJSON(data []byte) error {
 // Validate returns an error if Components does not comply with the OpenAPI spec.
 func (components *Components) Validate(ctx context.Context, opts ...ValidationOption) (err error) {
 	ctx = WithValidationOptions(ctx, opts...)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	schemas := make([]string, 0, len(components.Schemas))
 	for name := range components.Schemas {
@@ -96,10 +99,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range schemas {
 		v := components.Schemas[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("schema %q: %w", k, err)
+			return componentError(ctx, "schema", "schemas", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("schema %q: %w", k, err)
+			return componentError(ctx, "schema", "schemas", k, err)
 		}
 	}
 
@@ -111,10 +114,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range parameters {
 		v := components.Parameters[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("parameter %q: %w", k, err)
+			return componentError(ctx, "parameter", "parameters", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("parameter %q: %w", k, err)
+			return componentError(ctx, "parameter", "parameters", k, err)
 		}
 	}
 
@@ -126,10 +129,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range requestBodies {
 		v := components.RequestBodies[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("request body %q: %w", k, err)
+			return componentError(ctx, "request body", "requestBodies", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("request body %q: %w", k, err)
+			return componentError(ctx, "request body", "requestBodies", k, err)
 		}
 	}
 
@@ -141,10 +144,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range responses {
 		v := components.Responses[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("response %q: %w", k, err)
+			return componentError(ctx, "response", "responses", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("response %q: %w", k, err)
+			return componentError(ctx, "response", "responses", k, err)
 		}
 	}
 
@@ -156,10 +159,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range headers {
 		v := components.Headers[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("header %q: %w", k, err)
+			return componentError(ctx, "header", "headers", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("header %q: %w", k, err)
+			return componentError(ctx, "header", "headers", k, err)
 		}
 	}
 
@@ -171,10 +174,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range securitySchemes {
 		v := components.SecuritySchemes[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("security scheme %q: %w", k, err)
+			return componentError(ctx, "security scheme", "securitySchemes", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("security scheme %q: %w", k, err)
+			return componentError(ctx, "security scheme", "securitySchemes", k, err)
 		}
 	}
 
@@ -186,10 +189,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range examples {
 		v := components.Examples[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("example %q: %w", k, err)
+			return componentError(ctx, "example", "examples", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("example %q: %w", k, err)
+			return componentError(ctx, "example", "examples", k, err)
 		}
 	}
 
@@ -201,10 +204,10 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range links {
 		v := components.Links[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("link %q: %w", k, err)
+			return componentError(ctx, "link", "links", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("link %q: %w", k, err)
+			return componentError(ctx, "link", "links", k, err)
 		}
 	}
 
@@ -216,12 +219,179 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	for _, k := range callbacks {
 		v := components.Callbacks[k]
 		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("callback %q: %w", k, err)
+			return componentError(ctx, "callback", "callbacks", k, err)
 		}
 		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("callback %q: %w", k, err)
+			return componentError(ctx, "callback", "callbacks", k, err)
+		}
+	}
+
+	if getValidationOptions(ctx).componentNamesStrict {
+		if err = components.validateNamesUnique(); err != nil {
+			return
 		}
 	}
 
 	return validateExtensions(ctx, components.Extensions)
 }
+
+// componentError wraps err, found validating the component name of kind,
+// with the name of the component or, with WithSetPath, its JSON Pointer.
+func componentError(ctx context.Context, kind, field, name string, err error) error {
+	if prefix := getValidationOptions(ctx).pathPrefix; prefix != "" {
+		return fmt.Errorf("%s/components/%s/%s: %w", prefix, field, escapeJSONPointerToken(name), err)
+	}
+	return fmt.Errorf("%s %q: %w", kind, name, err)
+}
+
+// validateNamesUnique returns an error for each pair of components of different types sharing a name.
+func (components *Components) validateNamesUnique() error {
+	kinds := make(map[string][]string)
+	for name := range components.Schemas {
+		kinds[name] = append(kinds[name], "schema")
+	}
+	for name := range components.Parameters {
+		kinds[name] = append(kinds[name], "parameter")
+	}
+	for name := range components.RequestBodies {
+		kinds[name] = append(kinds[name], "request body")
+	}
+	for name := range components.Responses {
+		kinds[name] = append(kinds[name], "response")
+	}
+	for name := range components.Headers {
+		kinds[name] = append(kinds[name], "header")
+	}
+	for name := range components.SecuritySchemes {
+		kinds[name] = append(kinds[name], "security scheme")
+	}
+	for name := range components.Links {
+		kinds[name] = append(kinds[name], "link")
+	}
+	for name := range components.Callbacks {
+		kinds[name] = append(kinds[name], "callback")
+	}
+
+	names := make([]string, 0, len(kinds))
+	for name, nameKinds := range kinds {
+		if len(nameKinds) > 1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var me MultiError
+	for _, name := range names {
+		nameKinds := kinds[name]
+		for i := 0; i < len(nameKinds); i++ {
+			for j := i + 1; j < len(nameKinds); j++ {
+				me = append(me, fmt.Errorf("name %q is used by both a %s and a %s", name, nameKinds[i], nameKinds[j]))
+			}
+		}
+	}
+	if len(me) != 0 {
+		return me
+	}
+	return nil
+}
+
+// ForEachSchema calls fn with the name and value of each schema, in sorted name order,
+// skipping nil schemas and unresolved references. It stops at and returns the first error fn returns.
+// It does nothing on nil components.
+func (components *Components) ForEachSchema(fn func(name string, schema *Schema) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*SchemaRef { return components.Schemas },
+		func(ref *SchemaRef) *Schema { return ref.Value },
+		fn)
+}
+
+// ForEachParameter is like ForEachSchema for the parameters of the components.
+func (components *Components) ForEachParameter(fn func(name string, parameter *Parameter) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*ParameterRef { return components.Parameters },
+		func(ref *ParameterRef) *Parameter { return ref.Value },
+		fn)
+}
+
+// ForEachHeader is like ForEachSchema for the headers of the components.
+func (components *Components) ForEachHeader(fn func(name string, header *Header) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*HeaderRef { return components.Headers },
+		func(ref *HeaderRef) *Header { return ref.Value },
+		fn)
+}
+
+// ForEachRequestBody is like ForEachSchema for the request bodies of the components.
+func (components *Components) ForEachRequestBody(fn func(name string, requestBody *RequestBody) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*RequestBodyRef { return components.RequestBodies },
+		func(ref *RequestBodyRef) *RequestBody { return ref.Value },
+		fn)
+}
+
+// ForEachResponse is like ForEachSchema for the responses of the components.
+func (components *Components) ForEachResponse(fn func(name string, response *Response) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*ResponseRef { return components.Responses },
+		func(ref *ResponseRef) *Response { return ref.Value },
+		fn)
+}
+
+// ForEachSecurityScheme is like ForEachSchema for the security schemes of the components.
+func (components *Components) ForEachSecurityScheme(fn func(name string, securityScheme *SecurityScheme) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*SecuritySchemeRef { return components.SecuritySchemes },
+		func(ref *SecuritySchemeRef) *SecurityScheme { return ref.Value },
+		fn)
+}
+
+// ForEachExample is like ForEachSchema for the examples of the components.
+func (components *Components) ForEachExample(fn func(name string, example *Example) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*ExampleRef { return components.Examples },
+		func(ref *ExampleRef) *Example { return ref.Value },
+		fn)
+}
+
+// ForEachLink is like ForEachSchema for the links of the components.
+func (components *Components) ForEachLink(fn func(name string, link *Link) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*LinkRef { return components.Links },
+		func(ref *LinkRef) *Link { return ref.Value },
+		fn)
+}
+
+// ForEachCallback is like ForEachSchema for the callbacks of the components.
+func (components *Components) ForEachCallback(fn func(name string, callback *Callback) error) error {
+	return forEachComponent(components,
+		func(components *Components) map[string]*CallbackRef { return components.Callbacks },
+		func(ref *CallbackRef) *Callback { return ref.Value },
+		fn)
+}
+
+// forEachComponent calls fn with the name and the value of each reference of the components refs returns,
+// in sorted name order, skipping nil references and unresolved ones.
+// It stops at and returns the first error fn returns and does nothing on nil components.
+func forEachComponent[R, V any](components *Components, refs func(*Components) map[string]*R, value func(*R) *V, fn func(name string, value *V) error) error {
+	if components == nil {
+		return nil
+	}
+	m := refs(components)
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := m[name]
+		if ref == nil {
+			continue
+		}
+		if v := value(ref); v != nil {
+			if err := fn(name, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
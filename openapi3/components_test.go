@@ -0,0 +1,233 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentsValidateStrictNames(t *testing.T) {
+	components := &Components{
+		Schemas: Schemas{
+			"Error": NewObjectSchema().NewRef(),
+			"Pet":   NewObjectSchema().NewRef(),
+		},
+		Responses: Responses{
+			"Error": &ResponseRef{Value: NewResponse().WithDescription("error")},
+		},
+		Parameters: ParametersMap{
+			"Error": &ParameterRef{Value: NewQueryParameter("error").WithSchema(NewStringSchema())},
+			"limit": &ParameterRef{Value: NewQueryParameter("limit").WithSchema(NewIntegerSchema())},
+		},
+		RequestBodies: RequestBodies{
+			"Pet": &RequestBodyRef{Value: NewRequestBody().WithJSONSchema(NewObjectSchema())},
+		},
+	}
+
+	err := components.Validate(context.Background())
+	require.NoError(t, err)
+
+	err = components.Validate(context.Background(), WithStrictComponentNames())
+	require.Error(t, err)
+	me, ok := err.(MultiError)
+	require.True(t, ok)
+	var messages []string
+	for _, e := range me {
+		messages = append(messages, e.Error())
+	}
+	require.Equal(t, []string{
+		`name "Error" is used by both a schema and a parameter`,
+		`name "Error" is used by both a schema and a response`,
+		`name "Error" is used by both a parameter and a response`,
+		`name "Pet" is used by both a schema and a request body`,
+	}, messages)
+
+	delete(components.Responses, "Error")
+	delete(components.Parameters, "Error")
+	delete(components.RequestBodies, "Pet")
+	err = components.Validate(context.Background(), WithStrictComponentNames())
+	require.NoError(t, err)
+}
+
+func TestComponentsValidateWithSetPath(t *testing.T) {
+	schema := NewObjectSchema().WithProperty("name", NewStringSchema())
+	schema.Example = map[string]interface{}{"name": 42}
+	components := &Components{
+		Schemas: Schemas{
+			"Pet": schema.NewRef(),
+		},
+	}
+
+	err := components.Validate(context.Background())
+	require.ErrorContains(t, err, `schema "Pet": invalid example: Error at "/name"`)
+
+	err = components.Validate(context.Background(), WithSetPath("/x-apis/pets/"))
+	require.ErrorContains(t, err, `/x-apis/pets/components/schemas/Pet: invalid example: Error at "/x-apis/pets/name"`)
+	var schemaErr *SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	require.Equal(t, []string{"x-apis", "pets", "name"}, schemaErr.JSONPointer())
+}
+
+func TestComponentsMarshalJSONSorted(t *testing.T) {
+	components := Components{
+		Extensions: map[string]interface{}{"x-b": 1, "x-a": 2},
+		Schemas:    Schemas{"b": &SchemaRef{Ref: "#/b"}, "a": &SchemaRef{Ref: "#/a"}},
+		Parameters: ParametersMap{"b": &ParameterRef{Ref: "#/b"}, "a": &ParameterRef{Ref: "#/a"}},
+		Headers:    Headers{"b": &HeaderRef{Ref: "#/b"}, "a": &HeaderRef{Ref: "#/a"}},
+		RequestBodies: RequestBodies{
+			"b": &RequestBodyRef{Ref: "#/b"},
+			"a": &RequestBodyRef{Ref: "#/a"},
+		},
+		Responses: Responses{"b": &ResponseRef{Ref: "#/b"}, "a": &ResponseRef{Ref: "#/a"}},
+		SecuritySchemes: SecuritySchemes{
+			"b": &SecuritySchemeRef{Ref: "#/b"},
+			"a": &SecuritySchemeRef{Ref: "#/a"},
+		},
+		Examples:  Examples{"b": &ExampleRef{Ref: "#/b"}, "a": &ExampleRef{Ref: "#/a"}},
+		Links:     Links{"b": &LinkRef{Ref: "#/b"}, "a": &LinkRef{Ref: "#/a"}},
+		Callbacks: Callbacks{"b": &CallbackRef{Ref: "#/b"}, "a": &CallbackRef{Ref: "#/a"}},
+	}
+
+	sorted := `{"a":{"$ref":"#/a"},"b":{"$ref":"#/b"}}`
+	expected := `{"callbacks":` + sorted +
+		`,"examples":` + sorted +
+		`,"headers":` + sorted +
+		`,"links":` + sorted +
+		`,"parameters":` + sorted +
+		`,"requestBodies":` + sorted +
+		`,"responses":` + sorted +
+		`,"schemas":` + sorted +
+		`,"securitySchemes":` + sorted +
+		`,"x-a":2,"x-b":1}`
+	for i := 0; i < 10; i++ {
+		data, err := json.Marshal(components)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+}
+
+func TestComponentsForEach(t *testing.T) {
+	components := &Components{
+		Schemas: Schemas{
+			"Pet":      NewStringSchema().NewRef(),
+			"Error":    NewObjectSchema().NewRef(),
+			"Missing":  &SchemaRef{Ref: "#/components/schemas/Gone"},
+			"Nil":      nil,
+			"Category": NewIntegerSchema().NewRef(),
+		},
+		Parameters: ParametersMap{
+			"limit":  {Value: NewQueryParameter("limit")},
+			"offset": {Value: NewQueryParameter("offset")},
+		},
+		Responses: Responses{
+			"NotFound": {Value: NewResponse().WithDescription("not found")},
+			"Nil":      {},
+		},
+		Examples: Examples{
+			"pet": {Value: NewExample("dog")},
+		},
+		Headers: Headers{
+			"X-Rate-Limit": {Value: &Header{Parameter: Parameter{Schema: NewIntegerSchema().NewRef()}}},
+			"X-Missing":    {Ref: "#/components/headers/Gone"},
+		},
+		RequestBodies: RequestBodies{
+			"Pet": {Value: NewRequestBody().WithJSONSchema(NewObjectSchema())},
+		},
+		SecuritySchemes: SecuritySchemes{
+			"jwt":     {Value: NewJWTSecurityScheme()},
+			"api_key": {Value: NewSecurityScheme().WithType("apiKey").WithIn("header").WithName("X-API-Key")},
+			"nil":     nil,
+		},
+		Callbacks: Callbacks{
+			"onEvent": {Value: &Callback{"{$request.body#/url}": &PathItem{}}},
+		},
+	}
+
+	var names []string
+	err := components.ForEachSchema(func(name string, schema *Schema) error {
+		require.Same(t, components.Schemas[name].Value, schema)
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Category", "Error", "Pet"}, names)
+
+	errStop := errors.New("stop")
+	names = nil
+	err = components.ForEachParameter(func(name string, parameter *Parameter) error {
+		names = append(names, parameter.Name)
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, []string{"limit"}, names)
+
+	names = nil
+	err = components.ForEachResponse(func(name string, response *Response) error {
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"NotFound"}, names)
+
+	names = nil
+	err = components.ForEachExample(func(name string, example *Example) error {
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pet"}, names)
+
+	names = nil
+	err = components.ForEachHeader(func(name string, header *Header) error {
+		require.Same(t, components.Headers[name].Value, header)
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"X-Rate-Limit"}, names)
+
+	names = nil
+	err = components.ForEachRequestBody(func(name string, requestBody *RequestBody) error {
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Pet"}, names)
+
+	names = nil
+	err = components.ForEachSecurityScheme(func(name string, securityScheme *SecurityScheme) error {
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"api_key", "jwt"}, names)
+
+	names = nil
+	err = components.ForEachCallback(func(name string, callback *Callback) error {
+		require.Contains(t, *callback, "{$request.body#/url}")
+		names = append(names, name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"onEvent"}, names)
+
+	err = components.ForEachLink(func(name string, link *Link) error {
+		return errStop
+	})
+	require.NoError(t, err)
+
+	// Nil components have nothing to iterate over
+	var nilComponents *Components
+	require.NoError(t, nilComponents.ForEachSchema(func(string, *Schema) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachParameter(func(string, *Parameter) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachHeader(func(string, *Header) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachRequestBody(func(string, *RequestBody) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachResponse(func(string, *Response) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachSecurityScheme(func(string, *SecurityScheme) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachExample(func(string, *Example) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachLink(func(string, *Link) error { return errStop }))
+	require.NoError(t, nilComponents.ForEachCallback(func(string, *Callback) error { return errStop }))
+}
@@ -76,14 +76,14 @@ func (content Content) Get(mime string) *MediaType {
 	}
 	// If an exact match is not found then we strip all
 	// metadata from the mime type and only use the x/y
-	// portion.
+	// portion, which is case-insensitive.
 	i := strings.IndexByte(mime, ';')
 	if i < 0 {
 		// If there is no metadata then preserve the full mime type
 		// string for later wildcard searches.
 		i = len(mime)
 	}
-	mime = mime[:i]
+	mime = strings.ToLower(strings.TrimSpace(mime[:i]))
 	if v := content[mime]; v != nil {
 		return v
 	}
@@ -45,6 +45,18 @@ func TestContent_Get(t *testing.T) {
 			mime:    "application/json;encoding=utf-16",
 			want:    stripped,
 		},
+		{
+			name:    "stripped match with spaced parameters",
+			content: content,
+			mime:    "application/json ; charset=utf-8",
+			want:    stripped,
+		},
+		{
+			name:    "stripped match ignoring case",
+			content: content,
+			mime:    "Application/JSON; charset=utf-8",
+			want:    stripped,
+		},
 		{
 			name:    "wildcard match",
 			content: content,
@@ -3,12 +3,16 @@ package openapi3
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 )
 
 // Discriminator is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#discriminator-object
 type Discriminator struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	PropertyName string            `json:"propertyName" yaml:"propertyName"` // required
 	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
@@ -47,3 +51,125 @@ func (discriminator *Discriminator) Validate(ctx context.Context, opts ...Valida
 
 	return validateExtensions(ctx, discriminator.Extensions)
 }
+
+// validateFor returns an error if the discriminator cannot be used by parent:
+// the discriminator property must be required and each mapping value must reference
+// a schema that has this property.
+// Mapping values that are not alternatives of parent are resolved in the components
+// of the document being validated, if any, and skipped when they reference another document.
+func (discriminator *Discriminator) validateFor(ctx context.Context, parent *Schema) error {
+	name := discriminator.PropertyName
+	if name == "" {
+		return errors.New("value of propertyName must be a non-empty string")
+	}
+	if !discriminatorPropertyRequired(parent, name) {
+		return fmt.Errorf("property %q must be required", name)
+	}
+
+	var schemas Schemas
+	if doc, ok := ctx.Value(documentKey{}).(*T); ok && doc.Components != nil {
+		schemas = doc.Components.Schemas
+	}
+	values := make([]string, 0, len(discriminator.Mapping))
+	for value := range discriminator.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		ref := discriminator.Mapping[value]
+		target := discriminatorMappingTarget(parent, schemas, ref)
+		if target == nil {
+			if schemas == nil || !isLocalDiscriminatorMapping(ref) {
+				// No document to resolve ref in, or ref is in another one
+				continue
+			}
+			return fmt.Errorf("mapping %q: schema %q not found", value, ref)
+		}
+		if !schemaHasProperty(target, name, nil) {
+			return fmt.Errorf("mapping %q: schema %q has no property %q", value, ref, name)
+		}
+	}
+	return nil
+}
+
+// discriminatorPropertyRequired reports whether parent, or each of its oneOf or anyOf alternatives, requires name.
+func discriminatorPropertyRequired(parent *Schema, name string) bool {
+	if schemaRequires(parent, name, nil) {
+		return true
+	}
+	alternatives := append(append(SchemaRefs{}, parent.OneOf...), parent.AnyOf...)
+	if len(alternatives) == 0 {
+		return false
+	}
+	for _, alternative := range alternatives {
+		if alternative.Value == nil || !schemaRequires(alternative.Value, name, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+func discriminatorMappingTarget(parent *Schema, schemas Schemas, ref string) *Schema {
+	for _, list := range []SchemaRefs{parent.OneOf, parent.AnyOf} {
+		for _, alternative := range list {
+			if alternative.Ref == ref && alternative.Value != nil {
+				return alternative.Value
+			}
+		}
+	}
+	const prefix = "#/components/schemas/"
+	if strings.HasPrefix(ref, prefix) || !strings.ContainsAny(ref, "#/.") {
+		if target := schemas[strings.TrimPrefix(ref, prefix)]; target != nil {
+			return target.Value
+		}
+	}
+	return nil
+}
+
+// isLocalDiscriminatorMapping reports whether the mapping value ref is a schema name
+// or a reference within the same document, as opposed to e.g. "./dog.yaml#/Dog".
+func isLocalDiscriminatorMapping(ref string) bool {
+	return strings.HasPrefix(ref, "#") || !strings.ContainsAny(ref, "#/.")
+}
+
+// schemaRequires reports whether schema, or one of its allOf schemas, requires name.
+func schemaRequires(schema *Schema, name string, visited map[*Schema]struct{}) bool {
+	if visited == nil {
+		visited = make(map[*Schema]struct{})
+	} else if _, ok := visited[schema]; ok {
+		return false
+	}
+	visited[schema] = struct{}{}
+
+	for _, required := range schema.Required {
+		if required == name {
+			return true
+		}
+	}
+	for _, item := range schema.AllOf {
+		if item.Value != nil && schemaRequires(item.Value, name, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaHasProperty reports whether schema, or one of its allOf schemas, defines the property name.
+func schemaHasProperty(schema *Schema, name string, visited map[*Schema]struct{}) bool {
+	if visited == nil {
+		visited = make(map[*Schema]struct{})
+	} else if _, ok := visited[schema]; ok {
+		return false
+	}
+	visited[schema] = struct{}{}
+
+	if _, ok := schema.Properties[name]; ok {
+		return true
+	}
+	for _, item := range schema.AllOf {
+		if item.Value != nil && schemaHasProperty(item.Value, name, visited) {
+			return true
+		}
+	}
+	return false
+}
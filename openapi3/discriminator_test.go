@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -38,8 +39,8 @@ func TestParsingDiscriminator(t *testing.T) {
 					}
 				]
 			},
-			"Cat": {"enum": ["chat"]},
-			"Dog": {"enum": ["chien"]}
+			"Cat": {"type": "object", "required": ["pet_type"], "properties": {"pet_type": {"enum": ["cat"]}}},
+			"Dog": {"type": "object", "required": ["pet_type"], "properties": {"pet_type": {"enum": ["dog"]}}}
 		}
 	}
 }
@@ -54,3 +55,56 @@ func TestParsingDiscriminator(t *testing.T) {
 
 	require.Len(t, doc.Components.Schemas["MyResponseType"].Value.Discriminator.Mapping, 2)
 }
+
+func TestDiscriminatorValidate(t *testing.T) {
+	cat := NewObjectSchema().WithProperty("pet_type", NewStringSchema())
+	cat.Required = []string{"pet_type"}
+	dog := NewObjectSchema().WithProperty("name", NewStringSchema())
+	dog.Required = []string{"pet_type"}
+	doc := &T{
+		OpenAPI: "3.0.0",
+		Info:    &Info{Title: "pets", Version: "1.0.0"},
+		Paths:   Paths{},
+		Components: &Components{Schemas: Schemas{
+			"Cat": cat.NewRef(),
+			"Dog": dog.NewRef(),
+		}},
+	}
+	pet := &Schema{OneOf: SchemaRefs{
+		{Ref: "#/components/schemas/Cat", Value: cat},
+		{Ref: "#/components/schemas/Dog", Value: dog},
+	}}
+	doc.Components.Schemas["Pet"] = pet.NewRef()
+
+	pet.WithDiscriminator("pet_type", map[string]string{"cat": "#/components/schemas/Cat"})
+	require.Equal(t, &Discriminator{PropertyName: "pet_type", Mapping: map[string]string{"cat": "#/components/schemas/Cat"}}, pet.Discriminator)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	pet.WithDiscriminator("", nil)
+	err := doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid components: schema "Pet": invalid discriminator: value of propertyName must be a non-empty string`)
+
+	pet.WithDiscriminator("kind", nil)
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid components: schema "Pet": invalid discriminator: property "kind" must be required`)
+
+	pet.WithDiscriminator("pet_type", map[string]string{"dog": "#/components/schemas/Dog"})
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid components: schema "Pet": invalid discriminator: mapping "dog": schema "#/components/schemas/Dog" has no property "pet_type"`)
+
+	pet.WithDiscriminator("pet_type", map[string]string{"cat": "#/components/schemas/Kitten"})
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid components: schema "Pet": invalid discriminator: mapping "cat": schema "#/components/schemas/Kitten" not found`)
+
+	pet.WithDiscriminator("pet_type", map[string]string{"cat": "Cat"})
+	require.NoError(t, doc.Validate(context.Background()))
+
+	// Mapping values referencing other documents are only checked when they are alternatives
+	pet.OneOf = append(pet.OneOf, &SchemaRef{Ref: "./pets.yaml#/Dog", Value: dog})
+	pet.WithDiscriminator("pet_type", map[string]string{"cat": "./cat.yaml#/Cat", "fish": "https://example.com/fish.yaml"})
+	require.NoError(t, doc.Validate(context.Background()))
+
+	pet.WithDiscriminator("pet_type", map[string]string{"dog": "./pets.yaml#/Dog"})
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid components: schema "Pet": invalid discriminator: mapping "dog": schema "./pets.yaml#/Dog" has no property "pet_type"`)
+}
@@ -1,16 +1,39 @@
 package openapi3
 
-import "context"
+import (
+	"context"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
 
 func validateExampleValue(ctx context.Context, input interface{}, schema *Schema) error {
-	opts := make([]SchemaValidationOption, 0, 2)
+	opts := make([]SchemaValidationOption, 0, 3)
 
-	if vo := getValidationOptions(ctx); vo.examplesValidationAsReq {
+	vo := getValidationOptions(ctx)
+	if vo.examplesValidationAsReq {
 		opts = append(opts, VisitAsRequest())
 	} else if vo.examplesValidationAsRes {
 		opts = append(opts, VisitAsResponse())
 	}
+	if vo.schemaFormatAssertionsDisabled {
+		opts = append(opts, DisableFormatAssertions())
+	}
 	opts = append(opts, MultiErrors())
 
-	return schema.VisitJSON(input, opts...)
+	return prefixSchemaErrors(ctx, schema.VisitJSON(input, opts...))
+}
+
+// prefixSchemaErrors makes the JSON Pointers of the schema errors in err start with the path given to WithSetPath.
+func prefixSchemaErrors(ctx context.Context, err error) error {
+	prefix := getValidationOptions(ctx).pathPrefix
+	if err == nil || prefix == "" {
+		return err
+	}
+	tokens := strings.Split(strings.TrimPrefix(prefix, "/"), "/")
+	keys := make([]string, 0, len(tokens))
+	for i := len(tokens) - 1; i >= 0; i-- {
+		keys = append(keys, jsonpointer.Unescape(tokens[i]))
+	}
+	return markSchemaError(err, keys, nil)
 }
@@ -0,0 +1,81 @@
+package openapi3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExtensionsYAMLRoundTrip(t *testing.T) {
+	spec := []byte(`openapi: 3.0.3
+info:
+  title: MyAPI
+  version: "0.1"
+  x-audience:
+    - internal
+    - partners
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+      x-rate-limit:
+        burst: 10
+        window: 1s
+    x-owner: pets-team
+x-generated: true
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	err = enc.Encode(doc)
+	require.NoError(t, err)
+	require.Equal(t, string(spec), buf.String())
+}
+
+func TestExtensionsJSONOrder(t *testing.T) {
+	operation := NewOperation().WithSummary("list pets")
+	operation.Extensions = map[string]interface{}{"x-zeta": 1, "x-alpha": 2, "x-mu": 3}
+	operation.Responses = Responses{}
+
+	// Extensions are merged into maps, which encoding/json marshals with sorted keys
+	for i := 0; i < 10; i++ {
+		data, err := json.Marshal(operation)
+		require.NoError(t, err)
+		require.Equal(t, `{"responses":{},"summary":"list pets","x-alpha":2,"x-mu":3,"x-zeta":1}`, string(data))
+	}
+}
+
+func TestExtensionsUnknownFieldsReportedTogether(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.3
+info:
+  title: MyAPI
+  version: "0.1"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+      summry: typo
+      x-owner: pets-team
+      deprecatd: true
+      operationID: listPets
+`[1:])
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid paths: invalid path /pets: invalid operation GET: extra sibling fields: [deprecatd operationID summry]`)
+}
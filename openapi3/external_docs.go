@@ -5,13 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
+	"net/http"
 )
 
 // ExternalDocs is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#external-documentation-object
 type ExternalDocs struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	URL         string `json:"url,omitempty" yaml:"url,omitempty"`
@@ -53,9 +53,34 @@ func (e *ExternalDocs) Validate(ctx context.Context, opts ...ValidationOption) e
 	if e.URL == "" {
 		return errors.New("url is required")
 	}
-	if _, err := url.Parse(e.URL); err != nil {
+	if err := validateAbsoluteURL(e.URL); err != nil {
 		return fmt.Errorf("url is incorrect: %w", err)
 	}
+	if vo := getValidationOptions(ctx); vo.externalDocsValidationEnabled {
+		if err := checkURLAvailable(ctx, vo.httpClient, e.URL); err != nil {
+			return fmt.Errorf("url is unavailable: %w", err)
+		}
+	}
 
 	return validateExtensions(ctx, e.Extensions)
 }
+
+// checkURLAvailable sends a HEAD request to location and returns an error unless it responds with a 2xx status.
+func checkURLAvailable(ctx context.Context, client *http.Client, location string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, location, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("request returned status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package openapi3
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,7 +23,12 @@ func TestExternalDocs_Validate(t *testing.T) {
 		{
 			name:        "url is incorrect",
 			extDocs:     &ExternalDocs{URL: "ht tps://example.com"},
-			expectedErr: `url is incorrect: parse "ht tps://example.com": first path segment in URL cannot contain colon`,
+			expectedErr: `url is incorrect: parse "ht tps://example.com": invalid URI for request`,
+		},
+		{
+			name:        "url is relative",
+			extDocs:     &ExternalDocs{URL: "/docs"},
+			expectedErr: `url is incorrect: "/docs" is not an absolute URL`,
 		},
 		{
 			name:    "ok",
@@ -40,3 +47,36 @@ func TestExternalDocs_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalDocsValidateURLAvailable(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.URL.Path != "/docs" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	missing := &ExternalDocs{URL: ts.URL + "/missing"}
+	require.NoError(t, missing.Validate(ctx))
+	require.Empty(t, methods)
+
+	ctx = WithValidationOptions(ctx, WithValidateExternalDocs(true), WithValidationHTTPClient(ts.Client()))
+	err := missing.Validate(ctx)
+	require.EqualError(t, err, "url is unavailable: request returned status code 404")
+
+	docs := &ExternalDocs{URL: ts.URL + "/docs"}
+	require.NoError(t, docs.Validate(ctx))
+	require.Equal(t, []string{http.MethodHead, http.MethodHead}, methods)
+
+	doc := &T{
+		OpenAPI: "3.0.3",
+		Info:    &Info{Title: "title", Version: "1"},
+		Paths:   Paths{},
+		Tags:    Tags{{Name: "pets", ExternalDocs: missing}},
+	}
+	err = doc.Validate(context.Background(), WithValidateExternalDocs(true))
+	require.ErrorContains(t, err, "status code 404")
+}
@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 )
 
@@ -20,6 +21,18 @@ func ValidateIdentifier(value string) error {
 	return fmt.Errorf("identifier %q is not supported by OpenAPIv3 standard (regexp: %q)", value, identifierPattern)
 }
 
+// validateAbsoluteURL returns an error if value is not an absolute URL.
+func validateAbsoluteURL(value string) error {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("%q is not an absolute URL", value)
+	}
+	return nil
+}
+
 // Float64Ptr is a helper for defining OpenAPI schemas.
 func Float64Ptr(value float64) *float64 {
 	return &value
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
 )
 
+var semanticVersion = regexp.MustCompile(`^\d+\.\d+\.\d+`)
+
 // Info is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#info-object
 type Info struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Title          string   `json:"title" yaml:"title"` // Required
 	Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
@@ -19,6 +23,38 @@ type Info struct {
 	Version        string   `json:"version" yaml:"version"` // Required
 }
 
+func (info *Info) WithTitle(value string) *Info {
+	info.Title = value
+	return info
+}
+
+func (info *Info) WithDescription(value string) *Info {
+	info.Description = value
+	return info
+}
+
+func (info *Info) WithVersion(value string) *Info {
+	info.Version = value
+	return info
+}
+
+// WithLicense sets the license of the API.
+// As identifier and url are mutually exclusive, url is dropped when an SPDX identifier is given.
+func (info *Info) WithLicense(name, identifier, url string) *Info {
+	license := &License{Name: name, Identifier: identifier}
+	if identifier == "" {
+		license.URL = url
+	}
+	info.License = license
+	return info
+}
+
+// WithContact sets the contact information of the API.
+func (info *Info) WithContact(name, email, url string) *Info {
+	info.Contact = &Contact{Name: name, Email: email, URL: url}
+	return info
+}
+
 // MarshalJSON returns the JSON encoding of Info.
 func (info Info) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{}, 6+len(info.Extensions))
@@ -79,6 +115,9 @@ func (info *Info) Validate(ctx context.Context, opts ...ValidationOption) error
 	if info.Version == "" {
 		return errors.New("value of version must be a non-empty string")
 	}
+	if getValidationOptions(ctx).semanticVersioningEnabled && !semanticVersion.MatchString(info.Version) {
+		return fmt.Errorf("value of version %q is not a semantic version", info.Version)
+	}
 
 	if info.Title == "" {
 		return errors.New("value of title must be a non-empty string")
@@ -0,0 +1,53 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoBuilders(t *testing.T) {
+	info := (&Info{}).
+		WithTitle("Pets").
+		WithVersion("1.0.0").
+		WithLicense("Apache 2.0", "Apache-2.0", "https://www.apache.org/licenses/LICENSE-2.0.html").
+		WithContact("API Support", "support@example.com", "https://example.com/support")
+
+	require.Equal(t, &License{Name: "Apache 2.0", Identifier: "Apache-2.0"}, info.License)
+	require.Equal(t, &Contact{Name: "API Support", Email: "support@example.com", URL: "https://example.com/support"}, info.Contact)
+	require.NoError(t, info.Validate(context.Background()))
+
+	data, err := json.Marshal(info.License)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Apache 2.0","identifier":"Apache-2.0"}`, string(data))
+
+	info.WithLicense("MIT", "", "https://opensource.org/licenses/MIT")
+	require.Equal(t, &License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"}, info.License)
+	require.NoError(t, info.Validate(context.Background()))
+}
+
+func TestLicense_Validate(t *testing.T) {
+	license := &License{Name: "MIT", Identifier: "MIT", URL: "https://opensource.org/licenses/MIT"}
+	err := license.Validate(context.Background())
+	require.EqualError(t, err, "license identifier and url are mutually exclusive")
+}
+
+func TestInfo_ValidateVersion(t *testing.T) {
+	info := &Info{Title: "Pets"}
+	err := info.Validate(context.Background())
+	require.EqualError(t, err, "value of version must be a non-empty string")
+
+	info.Version = "v1"
+	err = info.Validate(context.Background())
+	require.NoError(t, err)
+	err = info.Validate(context.Background(), WithSemanticVersioning())
+	require.EqualError(t, err, `value of version "v1" is not a semantic version`)
+
+	for _, version := range []string{"1.0.0", "0.1.2-beta.1", "10.20.30+build"} {
+		info.Version = version
+		err = info.Validate(context.Background(), WithSemanticVersioning())
+		require.NoError(t, err)
+	}
+}
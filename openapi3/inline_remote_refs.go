@@ -0,0 +1,258 @@
+package openapi3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// InlineRemoteRefs moves the schemas loaded from http(s) references to the
+// components section of the document and replaces those references with local ones.
+//
+// Schemas are stored under the name returned by DefaultRefNameResolver,
+// suffixed with a number when this name is already used by a component or by another remote reference
+// (e.g. "pet2" for https://example.com/v2/pet.json after https://example.com/v1/pet.json).
+// The document is walked in sorted order, so names do not change from one call to the next.
+// The document must have been loaded with IsExternalRefsAllowed so that remote values are set.
+func (doc *T) InlineRemoteRefs(ctx context.Context) error {
+	doc.resetVisited()
+
+	if components := doc.Components; components != nil {
+		for _, name := range sortedMapKeys(components.Schemas) {
+			if err := doc.inlineRemoteSchemaRef(ctx, components.Schemas[name], ""); err != nil {
+				return err
+			}
+		}
+		for _, name := range sortedMapKeys(components.Parameters) {
+			if p := components.Parameters[name]; p != nil {
+				if err := doc.inlineRemoteParameter(ctx, p.Value); err != nil {
+					return err
+				}
+			}
+		}
+		if err := doc.inlineRemoteHeaders(ctx, components.Headers); err != nil {
+			return err
+		}
+		for _, name := range sortedMapKeys(components.RequestBodies) {
+			if r := components.RequestBodies[name]; r != nil && r.Value != nil {
+				if err := doc.inlineRemoteContent(ctx, r.Value.Content); err != nil {
+					return err
+				}
+			}
+		}
+		if err := doc.inlineRemoteResponses(ctx, components.Responses); err != nil {
+			return err
+		}
+		if err := doc.inlineRemoteCallbacks(ctx, components.Callbacks); err != nil {
+			return err
+		}
+	}
+
+	if err := doc.inlineRemotePaths(ctx, doc.Paths); err != nil {
+		return err
+	}
+	return doc.inlineRemotePaths(ctx, doc.Webhooks)
+}
+
+// remoteRef returns the absolute http(s) location of ref, which is relative to
+// the remote document at base when base is not empty.
+func remoteRef(ref, base string) string {
+	if ref == "" {
+		return ""
+	}
+	if base != "" {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return ""
+		}
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return ""
+		}
+		ref = baseURL.ResolveReference(refURL).String()
+	}
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return ref
+	}
+	return ""
+}
+
+func (doc *T) inlineRemoteSchemaRef(ctx context.Context, s *SchemaRef, base string) error {
+	if s == nil {
+		return nil
+	}
+	if ref := remoteRef(s.Ref, base); ref != "" {
+		if s.Value == nil {
+			return fmt.Errorf("remote reference %q was not loaded", ref)
+		}
+		if doc.Components == nil {
+			doc.Components = &Components{}
+		}
+		if doc.Components.Schemas == nil {
+			doc.Components.Schemas = make(Schemas)
+		}
+		name, ok := doc.remoteRefName(ref)
+		if !ok {
+			doc.Components.Schemas[name] = s.Value.NewRef()
+		}
+		s.Ref = "#/components/schemas/" + name
+		// References in the remote document are relative to it.
+		base = ref
+	}
+	return doc.inlineRemoteSchema(ctx, s.Value, base)
+}
+
+// remoteRefName returns the name of the schema component for the remote reference ref
+// and whether this component was already added for ref.
+// The name is not used by other components nor given to other remote references.
+func (doc *T) remoteRefName(ref string) (string, bool) {
+	base := DefaultRefNameResolver(ref)
+	name := base
+	for i := 2; ; i++ {
+		if other, ok := doc.visited.remoteRefs[name]; ok && other == ref {
+			return name, true
+		}
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	doc.visited.remoteRefs[name] = ref
+	return name, false
+}
+
+func (doc *T) inlineRemoteSchema(ctx context.Context, s *Schema, base string) error {
+	if s == nil || doc.isVisitedSchema(s) {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, list := range []SchemaRefs{s.AllOf, s.AnyOf, s.OneOf} {
+		for _, s2 := range list {
+			if err := doc.inlineRemoteSchemaRef(ctx, s2, base); err != nil {
+				return err
+			}
+		}
+	}
+	for _, name := range sortedMapKeys(s.Properties) {
+		if err := doc.inlineRemoteSchemaRef(ctx, s.Properties[name], base); err != nil {
+			return err
+		}
+	}
+	for _, ref := range []*SchemaRef{s.Not, s.AdditionalProperties.Schema, s.Items} {
+		if err := doc.inlineRemoteSchemaRef(ctx, ref, base); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (doc *T) inlineRemoteParameter(ctx context.Context, p *Parameter) error {
+	if p == nil {
+		return nil
+	}
+	if err := doc.inlineRemoteSchemaRef(ctx, p.Schema, ""); err != nil {
+		return err
+	}
+	return doc.inlineRemoteContent(ctx, p.Content)
+}
+
+func (doc *T) inlineRemoteContent(ctx context.Context, content Content) error {
+	for _, mime := range sortedMapKeys(content) {
+		mediaType := content[mime]
+		if mediaType == nil {
+			continue
+		}
+		if err := doc.inlineRemoteSchemaRef(ctx, mediaType.Schema, ""); err != nil {
+			return err
+		}
+		for _, name := range sortedMapKeys(mediaType.Encoding) {
+			if encoding := mediaType.Encoding[name]; encoding != nil {
+				if err := doc.inlineRemoteHeaders(ctx, encoding.Headers); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (doc *T) inlineRemoteHeaders(ctx context.Context, headers Headers) error {
+	for _, name := range sortedMapKeys(headers) {
+		if h := headers[name]; h != nil && h.Value != nil {
+			if err := doc.inlineRemoteParameter(ctx, &h.Value.Parameter); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (doc *T) inlineRemoteCallbacks(ctx context.Context, callbacks Callbacks) error {
+	for _, name := range sortedMapKeys(callbacks) {
+		if cb := callbacks[name]; cb != nil && cb.Value != nil {
+			if err := doc.inlineRemotePaths(ctx, *cb.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (doc *T) inlineRemoteResponses(ctx context.Context, responses Responses) error {
+	for _, code := range sortedMapKeys(responses) {
+		r := responses[code]
+		if r == nil || r.Value == nil {
+			continue
+		}
+		if err := doc.inlineRemoteHeaders(ctx, r.Value.Headers); err != nil {
+			return err
+		}
+		if err := doc.inlineRemoteContent(ctx, r.Value.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (doc *T) inlineRemotePaths(ctx context.Context, paths map[string]*PathItem) error {
+	for _, path := range sortedMapKeys(paths) {
+		pathItem := paths[path]
+		if pathItem == nil {
+			continue
+		}
+		for _, p := range pathItem.Parameters {
+			if p != nil {
+				if err := doc.inlineRemoteParameter(ctx, p.Value); err != nil {
+					return err
+				}
+			}
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedMapKeys(operations) {
+			op := operations[method]
+			for _, p := range op.Parameters {
+				if p != nil {
+					if err := doc.inlineRemoteParameter(ctx, p.Value); err != nil {
+						return err
+					}
+				}
+			}
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				if err := doc.inlineRemoteContent(ctx, op.RequestBody.Value.Content); err != nil {
+					return err
+				}
+			}
+			if err := doc.inlineRemoteResponses(ctx, op.Responses); err != nil {
+				return err
+			}
+			if err := doc.inlineRemoteCallbacks(ctx, op.Callbacks); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
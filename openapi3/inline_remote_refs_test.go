@@ -0,0 +1,128 @@
+package openapi3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineRemoteRefs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pet.json":
+			_, _ = w.Write([]byte(`{"type":"object","properties":{"tag":{"$ref":"tag.json"}}}`))
+		case "/tag.json":
+			_, _ = w.Write([]byte(`{"type":"string"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	spec := `
+openapi: 3.0.0
+info: {title: MyAPI, version: "0.1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "` + ts.URL + `/pet.json"}
+`
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = doc.InlineRemoteRefs(ctx)
+	require.NoError(t, err)
+	err = doc.InlineRemoteRefs(ctx)
+	require.NoError(t, err)
+
+	items := doc.Paths["/pets"].Get.Responses.Get(200).Value.Content.Get("application/json").Schema.Value.Items
+	require.Equal(t, "#/components/schemas/pet", items.Ref)
+	require.Len(t, doc.Components.Schemas, 2)
+	require.Equal(t, "#/components/schemas/tag", doc.Components.Schemas["pet"].Value.Properties["tag"].Ref)
+
+	data, err := doc.MarshalJSON()
+	require.NoError(t, err)
+	require.NotContains(t, string(data), ts.URL)
+
+	// The vendored document loads without network access.
+	ts.Close()
+	loader = NewLoader()
+	doc2, err := loader.LoadFromData(data)
+	require.NoError(t, err)
+	require.NoError(t, doc2.Validate(ctx))
+	require.Equal(t, SchemaTypes{"string"}, doc2.Components.Schemas["pet"].Value.Properties["tag"].Value.Type)
+}
+
+func TestInlineRemoteRefsNotLoaded(t *testing.T) {
+	doc := &T{
+		Components: &Components{
+			Schemas: Schemas{
+				"Pet": &SchemaRef{Ref: "https://schemas.example.com/pet.json"},
+			},
+		},
+	}
+	err := doc.InlineRemoteRefs(context.Background())
+	require.EqualError(t, err, `remote reference "https://schemas.example.com/pet.json" was not loaded`)
+	require.False(t, strings.HasPrefix(doc.Components.Schemas["Pet"].Ref, "#"))
+}
+
+func TestInlineRemoteRefsSameBasename(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pet.json":
+			_, _ = w.Write([]byte(`{"type":"object","properties":{"name":{"type":"string"}}}`))
+		case "/v2/pet.json":
+			_, _ = w.Write([]byte(`{"type":"object","properties":{"fullName":{"type":"string"}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	spec := `
+openapi: 3.0.0
+info: {title: MyAPI, version: "0.1"}
+paths: {}
+components:
+  schemas:
+    pet: {type: string}
+    PetV1: {$ref: "` + ts.URL + `/v1/pet.json"}
+    PetV2: {$ref: "` + ts.URL + `/v2/pet.json"}
+    Pets:
+      type: object
+      properties:
+        v1: {$ref: "` + ts.URL + `/v1/pet.json"}
+        v2: {$ref: "` + ts.URL + `/v2/pet.json"}
+`
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.InlineRemoteRefs(context.Background())
+	require.NoError(t, err)
+
+	// Names follow the sorted order of the document and never replace existing components
+	schemas := doc.Components.Schemas
+	require.Equal(t, "#/components/schemas/pet2", schemas["PetV1"].Ref)
+	require.Equal(t, "#/components/schemas/pet3", schemas["PetV2"].Ref)
+	require.Equal(t, "#/components/schemas/pet2", schemas["Pets"].Value.Properties["v1"].Ref)
+	require.Equal(t, "#/components/schemas/pet3", schemas["Pets"].Value.Properties["v2"].Ref)
+	require.Equal(t, SchemaTypes{"string"}, schemas["pet"].Value.Type)
+	require.Contains(t, schemas["pet2"].Value.Properties, "name")
+	require.Contains(t, schemas["pet3"].Value.Properties, "fullName")
+}
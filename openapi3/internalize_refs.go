@@ -431,4 +431,5 @@ func (doc *T) InternalizeRefs(ctx context.Context, refNameResolver func(ref stri
 	}
 
 	doc.derefPaths(doc.Paths, refNameResolver, false)
+	doc.derefPaths(doc.Webhooks, refNameResolver, false)
 }
@@ -23,7 +23,7 @@ func TestIssue341(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, `{"info":{"title":"test file","version":"n/a"},"openapi":"3.0.0","paths":{"/testpath":{"$ref":"testpath.yaml#/paths/~1testpath"}}}`, string(bs))
 
-	require.Equal(t, "string", doc.Paths["/testpath"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
+	require.Equal(t, SchemaTypes{"string"}, doc.Paths["/testpath"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
 
 	doc.InternalizeRefs(context.Background(), nil)
 	bs, err = doc.MarshalJSON()
@@ -40,7 +40,7 @@ info:
 	require.Equal(t, 2, len(doc.Components.Schemas))
 	require.Equal(t, 0, len(doc.Paths))
 
-	require.Equal(t, "string", doc.Components.Schemas["schema2"].Value.Properties["prop"].Value.Type)
+	require.Equal(t, SchemaTypes{"string"}, doc.Components.Schemas["schema2"].Value.Properties["prop"].Value.Type)
 }
 
 func TestExclusiveValuesOfValuesAdditionalProperties(t *testing.T) {
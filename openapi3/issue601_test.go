@@ -18,7 +18,7 @@ func TestIssue601(t *testing.T) {
 	require.NoError(t, err)
 
 	err = doc.Validate(sl.Context)
-	require.ErrorContains(t, err, `invalid components: schema "DiscoveryResult": invalid example: Error at "/type": property "type" is missing`)
+	require.ErrorContains(t, err, `invalid components: schema "DiscoveryResult": invalid property "namespaces": invalid additionalProperties: invalid example: Error at "/type": property "type" is missing`)
 	require.ErrorContains(t, err, `| Error at "/nsid": property "nsid" is missing`)
 
 	err = doc.Validate(sl.Context, DisableExamplesValidation())
@@ -17,6 +17,7 @@ type testCase struct {
 
 func TestIssue735(t *testing.T) {
 	DefineStringFormat("uuid", FormatOfStringForUUIDOfRFC4122)
+	defer DefineStringFormat("uuid", FormatOfStringForUUID)
 	DefineStringFormat("email", FormatOfStringForEmail)
 	DefineIPv4Format()
 	DefineIPv6Format()
@@ -94,7 +95,7 @@ func TestIssue735(t *testing.T) {
 		},
 		{
 			name:   "pattern",
-			schema: NewSchema().WithPattern("[0-9]"),
+			schema: mustWithPattern(NewSchema(), "[0-9]"),
 			value:  "foo",
 		},
 		{
@@ -21,7 +21,7 @@ func TestIssue767(t *testing.T) {
 		{
 			name: "default values disabled should fail with minProps 1",
 			schema: openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
-				"foo": {Type: "boolean", Default: true}}).WithMinProperties(1),
+				"foo": {Type: openapi3.SchemaTypes{"boolean"}, Default: true}}).WithMinProperties(1),
 			value: map[string]interface{}{},
 			opts: []openapi3.SchemaValidationOption{
 				openapi3.VisitAsRequest(),
@@ -31,7 +31,7 @@ func TestIssue767(t *testing.T) {
 		{
 			name: "default values enabled should pass with minProps 1",
 			schema: openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
-				"foo": {Type: "boolean", Default: true}}).WithMinProperties(1),
+				"foo": {Type: openapi3.SchemaTypes{"boolean"}, Default: true}}).WithMinProperties(1),
 			value: map[string]interface{}{},
 			opts: []openapi3.SchemaValidationOption{
 				openapi3.VisitAsRequest(),
@@ -42,8 +42,8 @@ func TestIssue767(t *testing.T) {
 		{
 			name: "default values enabled should pass with minProps 2",
 			schema: openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
-				"foo": {Type: "boolean", Default: true},
-				"bar": {Type: "boolean"},
+				"foo": {Type: openapi3.SchemaTypes{"boolean"}, Default: true},
+				"bar": {Type: openapi3.SchemaTypes{"boolean"}},
 			}).WithMinProperties(2),
 			value: map[string]interface{}{"bar": false},
 			opts: []openapi3.SchemaValidationOption{
@@ -55,8 +55,8 @@ func TestIssue767(t *testing.T) {
 		{
 			name: "default values enabled should fail with maxProps 1",
 			schema: openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
-				"foo": {Type: "boolean", Default: true},
-				"bar": {Type: "boolean"},
+				"foo": {Type: openapi3.SchemaTypes{"boolean"}, Default: true},
+				"bar": {Type: openapi3.SchemaTypes{"boolean"}},
 			}).WithMaxProperties(1),
 			value: map[string]interface{}{"bar": false},
 			opts: []openapi3.SchemaValidationOption{
@@ -68,8 +68,8 @@ func TestIssue767(t *testing.T) {
 		{
 			name: "default values disabled should pass with maxProps 1",
 			schema: openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
-				"foo": {Type: "boolean", Default: true},
-				"bar": {Type: "boolean"},
+				"foo": {Type: openapi3.SchemaTypes{"boolean"}, Default: true},
+				"bar": {Type: openapi3.SchemaTypes{"boolean"}},
 			}).WithMaxProperties(1),
 			value: map[string]interface{}{"bar": false},
 			opts: []openapi3.SchemaValidationOption{
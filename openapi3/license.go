@@ -9,19 +9,23 @@ import (
 // License is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#license-object
 type License struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
-	Name string `json:"name" yaml:"name"` // Required
-	URL  string `json:"url,omitempty" yaml:"url,omitempty"`
+	Name       string `json:"name" yaml:"name"` // Required
+	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+	URL        string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 // MarshalJSON returns the JSON encoding of License.
 func (license License) MarshalJSON() ([]byte, error) {
-	m := make(map[string]interface{}, 2+len(license.Extensions))
+	m := make(map[string]interface{}, 3+len(license.Extensions))
 	for k, v := range license.Extensions {
 		m[k] = v
 	}
 	m["name"] = license.Name
+	if x := license.Identifier; x != "" {
+		m["identifier"] = x
+	}
 	if x := license.URL; x != "" {
 		m["url"] = x
 	}
@@ -37,6 +41,7 @@ func (license *License) UnmarshalJSON(data []byte) error {
 	}
 	_ = json.Unmarshal(data, &x.Extensions)
 	delete(x.Extensions, "name")
+	delete(x.Extensions, "identifier")
 	delete(x.Extensions, "url")
 	*license = License(x)
 	return nil
@@ -50,5 +55,9 @@ func (license *License) Validate(ctx context.Context, opts ...ValidationOption)
 		return errors.New("value of license name must be a non-empty string")
 	}
 
+	if license.Identifier != "" && license.URL != "" {
+		return errors.New("license identifier and url are mutually exclusive")
+	}
+
 	return validateExtensions(ctx, license.Extensions)
 }
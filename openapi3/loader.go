@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -14,6 +15,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/invopop/yaml"
 )
@@ -37,8 +39,18 @@ type Loader struct {
 	// ReadFromURIFunc allows overriding the any file/URL reading func
 	ReadFromURIFunc ReadFromURIFunc
 
+	// UseStrict makes loading fail for documents that do not pass validation
+	UseStrict bool
+
+	// MaxRefDepth overrides CircularReferenceCounter when positive
+	MaxRefDepth int
+
 	Context context.Context
 
+	httpClient *http.Client
+	cache      LoaderCache
+	uriMapper  func(*url.URL) *url.URL
+
 	rootDir      string
 	rootLocation string
 
@@ -57,11 +69,37 @@ type Loader struct {
 	visitedSecurityScheme map[*SecurityScheme]struct{}
 }
 
-// NewLoader returns an empty Loader
-func NewLoader() *Loader {
-	return &Loader{
+// NewLoader returns a Loader configured with the given options
+func NewLoader(opts ...LoaderOption) *Loader {
+	loader := &Loader{
 		Context: context.Background(),
 	}
+	for _, opt := range opts {
+		opt(loader)
+	}
+	if loader.ReadFromURIFunc == nil {
+		loader.ReadFromURIFunc = loader.readFromURIFunc()
+	}
+	return loader
+}
+
+// SetURIMapper makes the loader fetch the URI returned by fn in place of each URI it reads,
+// e.g. to go through a mirror. Documents are still identified by their original URI,
+// which remains the key of the caches.
+// The mapping is done by ReadFromHTTP and ReadFromFile, hence by the default readers.
+func (loader *Loader) SetURIMapper(fn func(*url.URL) *url.URL) {
+	loader.uriMapper = fn
+}
+
+// mapURI returns the URI to fetch in place of location.
+func (loader *Loader) mapURI(location *url.URL) *url.URL {
+	if loader == nil || loader.uriMapper == nil {
+		return location
+	}
+	if mapped := loader.uriMapper(location); mapped != nil {
+		return mapped
+	}
+	return location
 }
 
 func (loader *Loader) resetVisitedPathItemRefs() {
@@ -74,6 +112,24 @@ func (loader *Loader) LoadFromURI(location *url.URL) (*T, error) {
 	return loader.loadFromURIInternal(location)
 }
 
+// LoadFromURIWithTimeout loads a spec from a remote URL, canceling the requests
+// still running after timeout.
+// Requests that time out return an error wrapping context.DeadlineExceeded,
+// which tells them apart from documents that fail to parse.
+func (loader *Loader) LoadFromURIWithTimeout(location *url.URL, timeout time.Duration) (*T, error) {
+	parent := loader.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	previous := loader.Context
+	loader.Context = ctx
+	defer func() { loader.Context = previous }()
+	return loader.LoadFromURI(location)
+}
+
 // LoadFromFile loads a spec from a local file path
 func (loader *Loader) LoadFromFile(location string) (*T, error) {
 	loader.rootDir = path.Dir(location)
@@ -148,9 +204,15 @@ func (loader *Loader) LoadFromData(data []byte) (*T, error) {
 	if err := unmarshal(data, doc); err != nil {
 		return nil, err
 	}
+	if err := checkDocumentVersion(doc); err != nil {
+		return nil, err
+	}
 	if err := loader.ResolveRefsIn(doc, nil); err != nil {
 		return nil, err
 	}
+	if err := loader.validateStrict(doc); err != nil {
+		return nil, err
+	}
 	return doc, nil
 }
 
@@ -177,13 +239,68 @@ func (loader *Loader) loadFromDataWithPathInternal(data []byte, location *url.UR
 	if err := unmarshal(data, doc); err != nil {
 		return nil, err
 	}
+	if location.Path == loader.rootLocation {
+		if err := checkDocumentVersion(doc); err != nil {
+			return nil, err
+		}
+	}
 	if err := loader.ResolveRefsIn(doc, location); err != nil {
 		return nil, err
 	}
+	if location.Path == loader.rootLocation {
+		if err := loader.validateStrict(doc); err != nil {
+			return nil, err
+		}
+	}
 
 	return doc, nil
 }
 
+// ErrSwaggerDocument is returned by Loader when given a Swagger (OpenAPI 2) document.
+// See the openapi2 and openapi2conv packages for such documents.
+type ErrSwaggerDocument struct {
+	Version string
+}
+
+func (err ErrSwaggerDocument) Error() string {
+	return fmt.Sprintf("cannot load swagger %q document as OpenAPI 3: use package openapi2", err.Version)
+}
+
+// ErrUnsupportedOpenAPIVersion is returned by Loader when given a document whose openapi version is not 3.x.
+type ErrUnsupportedOpenAPIVersion struct {
+	Version string
+}
+
+func (err ErrUnsupportedOpenAPIVersion) Error() string {
+	return fmt.Sprintf("unsupported openapi version %q: major version must be 3", err.Version)
+}
+
+// checkDocumentVersion fails early on documents that are not OpenAPI 3 ones.
+// Documents declaring no version at all are left to validation.
+func checkDocumentVersion(doc *T) error {
+	if doc.OpenAPI == "" {
+		if version, ok := doc.Extensions["swagger"]; ok {
+			return ErrSwaggerDocument{Version: fmt.Sprint(version)}
+		}
+		return nil
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") && doc.OpenAPI != "3" {
+		return ErrUnsupportedOpenAPIVersion{Version: doc.OpenAPI}
+	}
+	return nil
+}
+
+func (loader *Loader) validateStrict(doc *T) error {
+	if !loader.UseStrict {
+		return nil
+	}
+	ctx := loader.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return doc.Validate(ctx)
+}
+
 func unmarshal(data []byte, v interface{}) error {
 	// See https://github.com/getkin/kin-openapi/issues/680
 	if err := json.Unmarshal(data, v); err != nil {
@@ -264,6 +381,16 @@ func (loader *Loader) ResolveRefsIn(doc *T, location *url.URL) (err error) {
 		}
 	}
 
+	// Visit all webhooks
+	for _, pathItem := range doc.Webhooks {
+		if pathItem == nil {
+			continue
+		}
+		if err = loader.resolvePathItemRef(doc, pathItem, location); err != nil {
+			return
+		}
+	}
+
 	return
 }
 
@@ -371,6 +498,42 @@ func (loader *Loader) resolveComponent(doc *T, ref string, path *url.URL, resolv
 	}
 }
 
+// isSchemaAnchorRef tells whether ref points to a $anchor, e.g. "#node", rather than a JSON pointer.
+func isSchemaAnchorRef(ref string) bool {
+	i := strings.IndexByte(ref, '#')
+	return i >= 0 && i+1 < len(ref) && ref[i+1] != '/'
+}
+
+// resolveSchemaAnchor resolves a reference to the schema declaring its fragment as $anchor.
+// Component schemas and their inline subschemas are searched.
+func (loader *Loader) resolveSchemaAnchor(doc *T, ref string, path *url.URL, resolved interface{}) (
+	componentDoc *T,
+	componentPath *url.URL,
+	err error,
+) {
+	var fragment string
+	if componentDoc, fragment, componentPath, err = loader.resolveRef(doc, ref, path); err != nil {
+		return nil, nil, err
+	}
+	anchor := fragment[1:]
+	isAnchor := func(s *Schema) bool { return s.Anchor == anchor }
+
+	if components := componentDoc.Components; components != nil {
+		names := schemaNames(components.Schemas)
+		sort.Strings(names)
+		visited := make(map[*Schema]struct{})
+		for _, name := range names {
+			if schemaRef := components.Schemas[name]; schemaRef != nil {
+				if schema := schemaRef.Value.findSubschema(isAnchor, visited); schema != nil {
+					resolved.(*SchemaRef).Value = schema
+					return componentDoc, componentPath, nil
+				}
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("cannot find $anchor %q of reference %q", anchor, ref)
+}
+
 func readableType(x interface{}) string {
 	switch x.(type) {
 	case *Callback:
@@ -749,14 +912,18 @@ func (loader *Loader) resolveSchemaRef(doc *T, component *SchemaRef, documentPat
 			}
 			component.Value = &schema
 		} else {
-			if visitedLimit(visited, ref) {
+			if loader.visitedLimit(visited, ref) {
 				visited = append(visited, ref)
 				return fmt.Errorf("%s - %s", CircularReferenceError, strings.Join(visited, " -> "))
 			}
 			visited = append(visited, ref)
 
+			resolveComponent := loader.resolveComponent
+			if isSchemaAnchorRef(ref) {
+				resolveComponent = loader.resolveSchemaAnchor
+			}
 			var resolved SchemaRef
-			doc, componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
+			doc, componentPath, err := resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
 				return err
 			}
@@ -1039,12 +1206,16 @@ func unescapeRefString(ref string) string {
 	return strings.Replace(strings.Replace(ref, "~1", "/", -1), "~0", "~", -1)
 }
 
-func visitedLimit(visited []string, ref string) bool {
+func (loader *Loader) visitedLimit(visited []string, ref string) bool {
+	limit := CircularReferenceCounter
+	if loader.MaxRefDepth > 0 {
+		limit = loader.MaxRefDepth
+	}
 	visitedCount := 0
 	for _, v := range visited {
 		if v == ref {
 			visitedCount++
-			if visitedCount >= CircularReferenceCounter {
+			if visitedCount >= limit {
 				return true
 			}
 		}
@@ -79,11 +79,11 @@ components:
 	require.NoError(t, err)
 
 	expected, err := json.Marshal(&Schema{
-		Type:     "object",
+		Type:     SchemaTypes{"object"},
 		Required: []string{"id", "uri"},
 		Properties: Schemas{
-			"id":  {Value: &Schema{Type: "string"}},
-			"uri": {Value: &Schema{Type: "string"}},
+			"id":  {Value: &Schema{Type: SchemaTypes{"string"}}},
+			"uri": {Value: &Schema{Type: SchemaTypes{"string"}}},
 		},
 	},
 	)
@@ -0,0 +1,83 @@
+package openapi3
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// LoaderOption allows the modification of how OpenAPI documents are loaded.
+type LoaderOption func(loader *Loader)
+
+// LoaderCache stores the contents read from URI locations.
+type LoaderCache interface {
+	Get(uri string) ([]byte, bool)
+	Set(uri string, data []byte)
+}
+
+// WithExternalRefs enables or disables visiting other files.
+func WithExternalRefs(allowed bool) LoaderOption {
+	return func(loader *Loader) {
+		loader.IsExternalRefsAllowed = allowed
+	}
+}
+
+// WithStrict makes the loader validate documents once loaded.
+func WithStrict(strict bool) LoaderOption {
+	return func(loader *Loader) {
+		loader.UseStrict = strict
+	}
+}
+
+// WithHTTPClient makes the loader use the given http.Client to read remote URIs.
+// It has no effect when a ReadFromURIFunc is set.
+func WithHTTPClient(cl *http.Client) LoaderOption {
+	return func(loader *Loader) {
+		loader.httpClient = cl
+	}
+}
+
+// WithCache makes the loader cache the contents read from URIs in the given cache.
+// It has no effect when a ReadFromURIFunc is set.
+func WithCache(cache LoaderCache) LoaderOption {
+	return func(loader *Loader) {
+		loader.cache = cache
+	}
+}
+
+// WithMaxRefDepth sets how many times a same $ref may be visited before it is
+// considered circular. Defaults to CircularReferenceCounter.
+func WithMaxRefDepth(depth int) LoaderOption {
+	return func(loader *Loader) {
+		loader.MaxRefDepth = depth
+	}
+}
+
+func (loader *Loader) readFromURIFunc() ReadFromURIFunc {
+	if loader.httpClient == nil && loader.cache == nil {
+		return nil
+	}
+	cl := loader.httpClient
+	if cl == nil {
+		cl = http.DefaultClient
+	}
+	reader := ReadFromURIs(ReadFromHTTP(cl), ReadFromFile)
+	if cache := loader.cache; cache != nil {
+		reader = uriCache(cache, reader)
+	}
+	return reader
+}
+
+func uriCache(cache LoaderCache, reader ReadFromURIFunc) ReadFromURIFunc {
+	return func(loader *Loader, location *url.URL) ([]byte, error) {
+		uri := location.String()
+		if buf, ok := cache.Get(uri); ok {
+			return buf, nil
+		}
+		buf, err := reader(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		cache.Set(uri, buf)
+		return buf, nil
+	}
+}
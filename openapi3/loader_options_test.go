@@ -0,0 +1,65 @@
+package openapi3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mapLoaderCache map[string][]byte
+
+func (c mapLoaderCache) Get(uri string) ([]byte, bool) {
+	buf, ok := c[uri]
+	return buf, ok
+}
+
+func (c mapLoaderCache) Set(uri string, data []byte) {
+	c[uri] = data
+}
+
+func TestNewLoaderWithOptions(t *testing.T) {
+	loader := NewLoader(WithExternalRefs(true), WithStrict(true), WithMaxRefDepth(5))
+	require.True(t, loader.IsExternalRefsAllowed)
+	require.True(t, loader.UseStrict)
+	require.Equal(t, 5, loader.MaxRefDepth)
+	require.Nil(t, loader.ReadFromURIFunc)
+}
+
+func TestNewLoaderWithHTTPClientAndCache(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte(`{"openapi":"3.0.0","info":{"title":"MyAPI","version":"0.1"},"paths":{}}`))
+	}))
+	defer ts.Close()
+
+	cache := mapLoaderCache{}
+	loader := NewLoader(WithHTTPClient(ts.Client()), WithCache(cache))
+	require.NotNil(t, loader.ReadFromURIFunc)
+
+	location, err := url.Parse(ts.URL + "/spec.json")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		doc, err := NewLoader(WithHTTPClient(ts.Client()), WithCache(cache)).LoadFromURI(location)
+		require.NoError(t, err)
+		require.Equal(t, "MyAPI", doc.Info.Title)
+	}
+	require.Equal(t, 1, hits)
+	require.Contains(t, cache, location.String())
+}
+
+func TestNewLoaderWithStrict(t *testing.T) {
+	spec := []byte(`{"openapi":"3.0.0","info":{"title":"MyAPI"},"paths":{}}`)
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+
+	doc, err = NewLoader(WithStrict(true)).LoadFromData(spec)
+	require.EqualError(t, err, "invalid info: value of version must be a non-empty string")
+	require.Nil(t, doc)
+}
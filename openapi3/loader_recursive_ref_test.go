@@ -37,7 +37,7 @@ components:
 	require.NoError(t, err)
 	err = doc.Validate(loader.Context)
 	require.NoError(t, err)
-	require.Equal(t, "object", doc.Components.
+	require.Equal(t, SchemaTypes{"object"}, doc.Components.
 		// Complex
 		Schemas["Complex"].
 		// parent
@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -289,7 +291,80 @@ func TestLoadFromRemoteURL(t *testing.T) {
 	doc, err := loader.LoadFromURI(url)
 	require.NoError(t, err)
 
-	require.Equal(t, "string", doc.Components.Schemas["TestSchema"].Value.Type)
+	require.Equal(t, SchemaTypes{"string"}, doc.Components.Schemas["TestSchema"].Value.Type)
+}
+
+func TestLoadFromURIWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow.json":
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		case "/invalid.json":
+			w.Write([]byte(`{"openapi":`))
+		default:
+			http.ServeFile(w, r, "testdata/test.openapi.json")
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	loader := NewLoader(WithHTTPClient(ts.Client()))
+	load := func(path string) (*T, error) {
+		location, err := url.Parse(ts.URL + path)
+		require.NoError(t, err)
+		return loader.LoadFromURIWithTimeout(location, 100*time.Millisecond)
+	}
+
+	doc, err := load("/test.openapi.json")
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+
+	_, err = load("/slow.json")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = load("/invalid.json")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, context.DeadlineExceeded)
+
+	require.Equal(t, context.Background(), loader.Context)
+}
+
+func TestLoaderSetURIMapper(t *testing.T) {
+	var requested []string
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		http.ServeFile(w, r, "testdata/test.openapi.json")
+	}))
+	defer mirror.Close()
+	mirrorURL, err := url.Parse(mirror.URL)
+	require.NoError(t, err)
+
+	cache := mapLoaderCache{}
+	loader := NewLoader(WithHTTPClient(mirror.Client()), WithCache(cache))
+	loader.SetURIMapper(func(location *url.URL) *url.URL {
+		if location.Host != "schemas.example.com" {
+			return nil
+		}
+		mapped := *location
+		mapped.Scheme, mapped.Host = mirrorURL.Scheme, mirrorURL.Host
+		return &mapped
+	})
+
+	location, err := url.Parse("https://schemas.example.com/specs/test.openapi.json")
+	require.NoError(t, err)
+	doc, err := loader.LoadFromURI(location)
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+	require.Equal(t, []string{"/specs/test.openapi.json"}, requested)
+	require.Contains(t, cache, "https://schemas.example.com/specs/test.openapi.json")
+
+	_, err = loader.LoadFromURI(location)
+	require.NoError(t, err)
+	require.Len(t, requested, 1)
 }
 
 func TestLoadWithReferenceInReference(t *testing.T) {
@@ -300,7 +375,7 @@ func TestLoadWithReferenceInReference(t *testing.T) {
 	require.NotNil(t, doc)
 	err = doc.Validate(loader.Context)
 	require.NoError(t, err)
-	require.Equal(t, "string", doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["definition_reference"].Value.Type)
+	require.Equal(t, SchemaTypes{"string"}, doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["definition_reference"].Value.Type)
 }
 
 func TestLoadWithRecursiveReferenceInLocalReferenceInParentSubdir(t *testing.T) {
@@ -311,7 +386,7 @@ func TestLoadWithRecursiveReferenceInLocalReferenceInParentSubdir(t *testing.T)
 	require.NotNil(t, doc)
 	err = doc.Validate(loader.Context)
 	require.NoError(t, err)
-	require.Equal(t, "object", doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["definition_reference"].Value.Type)
+	require.Equal(t, SchemaTypes{"object"}, doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["definition_reference"].Value.Type)
 }
 
 func TestLoadWithRecursiveReferenceInReferenceInLocalReference(t *testing.T) {
@@ -322,7 +397,7 @@ func TestLoadWithRecursiveReferenceInReferenceInLocalReference(t *testing.T) {
 	require.NotNil(t, doc)
 	err = doc.Validate(loader.Context)
 	require.NoError(t, err)
-	require.Equal(t, "integer", doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["data"].Value.Properties["definition_reference"].Value.Properties["ref_prop_part"].Value.Properties["idPart"].Value.Type)
+	require.Equal(t, SchemaTypes{"integer"}, doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["data"].Value.Properties["definition_reference"].Value.Properties["ref_prop_part"].Value.Properties["idPart"].Value.Type)
 	require.Equal(t, "int64", doc.Paths["/api/test/ref/in/ref"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["data"].Value.Properties["definition_reference"].Value.Properties["ref_prop_part"].Value.Properties["idPart"].Value.Format)
 }
 
@@ -462,7 +537,7 @@ func TestLoadYamlFileWithExternalPathRef(t *testing.T) {
 	require.NoError(t, err)
 
 	require.NotNil(t, doc.Paths["/test"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
-	require.Equal(t, "string", doc.Paths["/test"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
+	require.Equal(t, SchemaTypes{"string"}, doc.Paths["/test"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
 }
 
 func TestResolveResponseLinkRef(t *testing.T) {
@@ -621,3 +696,22 @@ servers:
 		})
 	}
 }
+
+func TestLoaderDocumentVersion(t *testing.T) {
+	loader := NewLoader()
+
+	_, err := loader.LoadFromData([]byte(`{"swagger": "2.0", "info": {"title": "API", "version": "1.0"}, "paths": {}}`))
+	require.Equal(t, ErrSwaggerDocument{Version: "2.0"}, err)
+	require.EqualError(t, err, `cannot load swagger "2.0" document as OpenAPI 3: use package openapi2`)
+
+	_, err = loader.LoadFromData([]byte("openapi: 2.1.0\ninfo: {title: API, version: '1.0'}\npaths: {}\n"))
+	require.Equal(t, ErrUnsupportedOpenAPIVersion{Version: "2.1.0"}, err)
+	require.EqualError(t, err, `unsupported openapi version "2.1.0": major version must be 3`)
+
+	_, err = loader.LoadFromDataWithPath([]byte(`{"swagger": "2.0"}`), &url.URL{Path: "testdata/swagger.json"})
+	require.Equal(t, ErrSwaggerDocument{Version: "2.0"}, err)
+
+	doc, err := loader.LoadFromData([]byte(`{"openapi": "3.0.0", "info": {"title": "API", "version": "1.0"}, "paths": {}}`))
+	require.NoError(t, err)
+	require.Equal(t, "3.0.0", doc.OpenAPI)
+}
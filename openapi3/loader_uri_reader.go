@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -46,12 +47,18 @@ var DefaultReadFromURI = URIMapCache(ReadFromURIs(ReadFromHTTP(http.DefaultClien
 // ReadFromHTTP returns a ReadFromURIFunc which uses the given http.Client to
 // read the contents from a remote HTTP URI. This client may be customized to
 // implement timeouts, RFC 7234 caching, etc.
+// Requests are canceled with the loader's Context and sent to the URI mapped by the loader, see SetURIMapper.
 func ReadFromHTTP(cl *http.Client) ReadFromURIFunc {
 	return func(loader *Loader, location *url.URL) ([]byte, error) {
+		location = loader.mapURI(location)
 		if location.Scheme == "" || location.Host == "" {
 			return nil, ErrURINotSupported
 		}
-		req, err := http.NewRequest("GET", location.String(), nil)
+		ctx := loader.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		req, err := http.NewRequestWithContext(ctx, "GET", location.String(), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -69,6 +76,7 @@ func ReadFromHTTP(cl *http.Client) ReadFromURIFunc {
 
 // ReadFromFile is a ReadFromURIFunc which reads local file URIs.
 func ReadFromFile(loader *Loader, location *url.URL) ([]byte, error) {
+	location = loader.mapURI(location)
 	if location.Host != "" {
 		return nil, ErrURINotSupported
 	}
@@ -71,3 +71,20 @@ func mediaType() *MediaType {
 		},
 	}
 }
+
+func TestMediaTypeValidateExamples(t *testing.T) {
+	mediaType := NewMediaType().
+		WithSchema(NewIntegerSchema()).
+		WithExample("valid", 42).
+		WithExample("invalid", "forty-two")
+
+	err := mediaType.Validate(context.Background())
+	require.ErrorContains(t, err, "example invalid: ")
+
+	err = mediaType.Validate(context.Background(), DisableExamplesValidation())
+	require.NoError(t, err)
+
+	delete(mediaType.Examples, "invalid")
+	err = mediaType.Validate(context.Background())
+	require.NoError(t, err)
+}
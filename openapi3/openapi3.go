@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // T is the root of an OpenAPI v3 document
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#openapi-object
 type T struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	OpenAPI      string               `json:"openapi" yaml:"openapi"` // Required
 	Components   *Components          `json:"components,omitempty" yaml:"components,omitempty"`
@@ -20,11 +24,17 @@ type T struct {
 	Servers      Servers              `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Tags         Tags                 `json:"tags,omitempty" yaml:"tags,omitempty"`
 	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	// Webhooks were introduced in OpenAPI 3.1
+	Webhooks map[string]*PathItem `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+	// Schema is the URI of the dialect of the document, e.g. SchemaOAS31MetaBase
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
 
 	visited visitedComponent
 }
 
 // MarshalJSON returns the JSON encoding of T.
+// Nullable schemas are encoded as per the OpenAPI version of the document:
+// with `nullable: true` before 3.1 and with a "null" type from 3.1 on.
 func (doc T) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{}, 4+len(doc.Extensions))
 	for k, v := range doc.Extensions {
@@ -48,7 +58,17 @@ func (doc T) MarshalJSON() ([]byte, error) {
 	if x := doc.ExternalDocs; x != nil {
 		m["externalDocs"] = x
 	}
-	return json.Marshal(m)
+	if x := doc.Webhooks; len(x) != 0 {
+		m["webhooks"] = x
+	}
+	if x := doc.Schema; x != "" {
+		m["$schema"] = x
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return doc.encodeNullableSchemas(data)
 }
 
 // UnmarshalJSON sets T to a copy of data.
@@ -67,11 +87,102 @@ func (doc *T) UnmarshalJSON(data []byte) error {
 	delete(x.Extensions, "servers")
 	delete(x.Extensions, "tags")
 	delete(x.Extensions, "externalDocs")
+	delete(x.Extensions, "webhooks")
+	delete(x.Extensions, "$schema")
 	*doc = T(x)
 	return nil
 }
 
-func (doc *T) AddOperation(path string, method string, operation *Operation) {
+// Dialects of OpenAPI 3.1 documents recognized in T.Schema.
+const (
+	SchemaOAS31MetaBase    = "https://spec.openapis.org/oas/3.1/meta/base"
+	SchemaOAS31DialectBase = "https://spec.openapis.org/oas/3.1/dialect/base"
+)
+
+// isKnownSchemaDialect tells whether uri is a dialect T.Validate knows how to validate.
+func isKnownSchemaDialect(uri string) bool {
+	switch uri {
+	case SchemaOAS31MetaBase, SchemaOAS31DialectBase:
+		return true
+	}
+	return false
+}
+
+// NewT returns an OpenAPI 3.0.3 document with an empty Info and no paths.
+func NewT() *T {
+	return &T{
+		OpenAPI: "3.0.3",
+		Info:    &Info{},
+		Paths:   make(Paths),
+	}
+}
+
+func (doc *T) WithOpenAPI(version string) *T {
+	doc.OpenAPI = version
+	return doc
+}
+
+func (doc *T) WithInfo(info *Info) *T {
+	doc.Info = info
+	return doc
+}
+
+func (doc *T) WithPaths(paths Paths) *T {
+	doc.Paths = paths
+	return doc
+}
+
+func (doc *T) WithComponents(components *Components) *T {
+	doc.Components = components
+	return doc
+}
+
+func (doc *T) WithServers(servers Servers) *T {
+	doc.Servers = servers
+	return doc
+}
+
+func (doc *T) WithSecurity(security SecurityRequirements) *T {
+	doc.Security = security
+	return doc
+}
+
+func (doc *T) WithTags(tags Tags) *T {
+	doc.Tags = tags
+	return doc
+}
+
+func (doc *T) WithExternalDocs(externalDocs *ExternalDocs) *T {
+	doc.ExternalDocs = externalDocs
+	return doc
+}
+
+// ErrOperationAlreadyExists is returned by AddOperation when the path already has an operation for the method.
+var ErrOperationAlreadyExists = errors.New("operation already exists")
+
+// AddOperation adds the operation for path and HTTP method, creating the path item if needed.
+// It returns ErrOperationAlreadyExists instead of replacing an existing operation (see SetOperation)
+// and an error if the method is not supported.
+func (doc *T) AddOperation(path string, method string, operation *Operation) error {
+	pathItem := doc.Paths[path]
+	if pathItem == nil {
+		pathItem = &PathItem{}
+	} else if _, ok := pathItem.Operations()[strings.ToUpper(method)]; ok {
+		return fmt.Errorf("%w: %s %s", ErrOperationAlreadyExists, strings.ToUpper(method), path)
+	}
+	if err := pathItem.SetOperation(method, operation); err != nil {
+		return err
+	}
+	if doc.Paths == nil {
+		doc.Paths = make(Paths)
+	}
+	doc.Paths[path] = pathItem
+	return nil
+}
+
+// SetOperation sets the operation for path and HTTP method, replacing any existing one.
+// It panics if the method is not supported.
+func (doc *T) SetOperation(path string, method string, operation *Operation) {
 	if doc.Paths == nil {
 		doc.Paths = make(Paths)
 	}
@@ -80,76 +191,207 @@ func (doc *T) AddOperation(path string, method string, operation *Operation) {
 		pathItem = &PathItem{}
 		doc.Paths[path] = pathItem
 	}
-	pathItem.SetOperation(method, operation)
+	pathItem.MustSetOperation(method, operation)
 }
 
 func (doc *T) AddServer(server *Server) {
 	doc.Servers = append(doc.Servers, server)
 }
 
+// ErrOperationNotFound is returned by FindOperationByID when no operation has the given operationId.
+var ErrOperationNotFound = errors.New("operation not found")
+
+// FindOperationByID returns the operation with the given operationId, along with its path and HTTP method.
+// Operations without an operationId are never found.
+func (doc *T) FindOperationByID(operationID string) (*Operation, string, string, error) {
+	if operationID == "" {
+		return nil, "", "", fmt.Errorf("%w: %q", ErrOperationNotFound, operationID)
+	}
+	for path, pathItem := range doc.Paths {
+		if pathItem == nil {
+			continue
+		}
+		for method, operation := range pathItem.Operations() {
+			if operation.OperationID == operationID {
+				return operation, path, method, nil
+			}
+		}
+	}
+	return nil, "", "", fmt.Errorf("%w: %q", ErrOperationNotFound, operationID)
+}
+
+// documentKey is the context key of the document being validated.
+type documentKey struct{}
+
 // Validate returns an error if T does not comply with the OpenAPI spec.
 // Validations Options can be provided to modify the validation behavior.
 func (doc *T) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, documentKey{}, doc)
 
 	if doc.OpenAPI == "" {
 		return errors.New("value of openapi must be a non-empty string")
 	}
+	if err := validateOpenAPIVersion(ctx, doc.OpenAPI); err != nil {
+		return err
+	}
+	if isKnownSchemaDialect(doc.Schema) && openAPIMinorVersion(doc.OpenAPI) < 1 {
+		return fmt.Errorf("$schema %q is an OpenAPI 3.1 dialect, not supported by openapi %q", doc.Schema, doc.OpenAPI)
+	}
 
-	var wrap func(error) error
+	// Without a resolver, all unresolved schema references are reported at once.
+	if getValidationOptions(ctx).refResolver == nil {
+		if err := doc.unresolvedRefs(); err != nil {
+			return err
+		}
+	}
 
-	wrap = func(e error) error { return fmt.Errorf("invalid components: %w", e) }
 	if v := doc.Components; v != nil {
 		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+			return fmt.Errorf("invalid components: %w", err)
 		}
 	}
 
-	wrap = func(e error) error { return fmt.Errorf("invalid info: %w", e) }
-	if v := doc.Info; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+	// The other sections only depend on components and are validated concurrently.
+	// The first error in the order of the sections below is returned.
+	validators := []func() error{
+		func() error {
+			wrap := func(e error) error { return fmt.Errorf("invalid info: %w", e) }
+			if v := doc.Info; v != nil {
+				if err := v.Validate(ctx); err != nil {
+					return wrap(err)
+				}
+			} else {
+				return wrap(errors.New("must be an object"))
+			}
+			return nil
+		},
+		func() error {
+			wrap := func(e error) error { return fmt.Errorf("invalid paths: %w", e) }
+			if v := doc.Paths; v != nil {
+				if err := v.Validate(ctx); err != nil {
+					return wrap(err)
+				}
+			} else {
+				return wrap(errors.New("must be an object"))
+			}
+			return nil
+		},
+		func() error {
+			if v := doc.Security; v != nil {
+				if err := v.Validate(ctx); err != nil {
+					return fmt.Errorf("invalid security: %w", err)
+				}
+			}
+			return nil
+		},
+		func() error {
+			if v := doc.Servers; v != nil {
+				if err := v.Validate(ctx); err != nil {
+					return fmt.Errorf("invalid servers: %w", err)
+				}
+			}
+			return nil
+		},
+		func() error {
+			if v := doc.Tags; v != nil {
+				if err := v.Validate(ctx); err != nil {
+					return fmt.Errorf("invalid tags: %w", err)
+				}
+			}
+			return nil
+		},
+		func() error {
+			if v := doc.ExternalDocs; v != nil {
+				if err := v.Validate(ctx); err != nil {
+					return fmt.Errorf("invalid external docs: %w", err)
+				}
+			}
+			return nil
+		},
+		func() error {
+			wrap := func(e error) error { return fmt.Errorf("invalid webhooks: %w", e) }
+			if v := doc.Webhooks; len(v) != 0 {
+				if openAPIMinorVersion(doc.OpenAPI) < 1 {
+					return wrap(fmt.Errorf("webhooks are not supported by openapi %q", doc.OpenAPI))
+				}
+				names := make([]string, 0, len(v))
+				for name := range v {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					if pathItem := v[name]; pathItem != nil {
+						if err := pathItem.Validate(ctx); err != nil {
+							return wrap(fmt.Errorf("webhook %q: %w", name, err))
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+	errs := make([]error, len(validators))
+	var wg sync.WaitGroup
+	wg.Add(len(validators))
+	for i, validate := range validators {
+		go func(i int, validate func() error) {
+			defer wg.Done()
+			errs[i] = validate()
+		}(i, validate)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
-	} else {
-		return wrap(errors.New("must be an object"))
 	}
 
-	wrap = func(e error) error { return fmt.Errorf("invalid paths: %w", e) }
-	if v := doc.Paths; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
-		}
-	} else {
-		return wrap(errors.New("must be an object"))
+	if err := doc.validateSecuritySchemesDefined(); err != nil {
+		return err
 	}
 
-	wrap = func(e error) error { return fmt.Errorf("invalid security: %w", e) }
-	if v := doc.Security; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+	if getValidationOptions(ctx).unusedSchemasDisallowed {
+		if err := doc.validateSchemasReferenced(); err != nil {
+			return fmt.Errorf("invalid components: %w", err)
 		}
 	}
 
-	wrap = func(e error) error { return fmt.Errorf("invalid servers: %w", e) }
-	if v := doc.Servers; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
-		}
+	return validateExtensions(ctx, doc.Extensions)
+}
+
+// openAPIMinorVersion returns the minor version of an OpenAPI version, 0 if missing.
+func openAPIMinorVersion(version string) uint64 {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0
 	}
+	minor, _ := strconv.ParseUint(parts[1], 10, 64)
+	return minor
+}
 
-	wrap = func(e error) error { return fmt.Errorf("invalid tags: %w", e) }
-	if v := doc.Tags; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+// validateOpenAPIVersion checks version is a published OpenAPI 3 version: 3.0.x or 3.1.x
+func validateOpenAPIVersion(ctx context.Context, version string) error {
+	parts := strings.SplitN(version, ".", 3)
+	if parts[0] != "3" {
+		return fmt.Errorf("unsupported openapi version %q: major version must be 3", version)
+	}
+	if len(parts) > 1 {
+		minor, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unsupported openapi version %q: minor version must be a non-negative integer", version)
+		}
+		if minor > 1 && !getValidationOptions(ctx).futureVersionsAllowed {
+			return fmt.Errorf("unsupported openapi version %q: minor version must be 0 or 1", version)
 		}
 	}
-
-	wrap = func(e error) error { return fmt.Errorf("invalid external docs: %w", e) }
-	if v := doc.ExternalDocs; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+	if len(parts) > 2 {
+		if _, err := strconv.ParseUint(parts[2], 10, 64); err != nil {
+			return fmt.Errorf("unsupported openapi version %q: patch version must be a non-negative integer", version)
 		}
 	}
-
-	return validateExtensions(ctx, doc.Extensions)
+	return nil
 }
@@ -3,8 +3,11 @@ package openapi3
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/invopop/yaml"
 	"github.com/stretchr/testify/require"
@@ -429,6 +432,11 @@ components:
 			spec:        strings.Replace(spec, version, "openapi: ''", 1),
 			expectedErr: "value of openapi must be a non-empty string",
 		},
+		{
+			name:        "version is not supported",
+			spec:        strings.Replace(spec, version, "openapi: 3.5.0", 1),
+			expectedErr: `unsupported openapi version "3.5.0": minor version must be 0 or 1`,
+		},
 		{
 			name:        "info section is missing",
 			spec:        strings.Replace(spec, info, ``, 1),
@@ -468,3 +476,244 @@ components:
 		})
 	}
 }
+
+func TestValidateOpenAPIVersion(t *testing.T) {
+	for version, expectedErr := range map[string]string{
+		"3":       "",
+		"3.0":     "",
+		"3.0.3":   "",
+		"3.1.0":   "",
+		"2.0":     `unsupported openapi version "2.0": major version must be 3`,
+		"3.x":     `unsupported openapi version "3.x": minor version must be a non-negative integer`,
+		"3.2.0":   `unsupported openapi version "3.2.0": minor version must be 0 or 1`,
+		"3.0.-1":  `unsupported openapi version "3.0.-1": patch version must be a non-negative integer`,
+		"3.0.0.0": `unsupported openapi version "3.0.0.0": patch version must be a non-negative integer`,
+	} {
+		err := validateOpenAPIVersion(context.Background(), version)
+		if expectedErr != "" {
+			require.EqualError(t, err, expectedErr)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	ctx := WithValidationOptions(context.Background(), WithAllowFutureVersions())
+	err := validateOpenAPIVersion(ctx, "3.2.0")
+	require.NoError(t, err)
+}
+
+func TestWebhooks(t *testing.T) {
+	spec := func(version string) []byte {
+		return []byte(`
+openapi: ` + version + `
+info: {title: MyAPI, version: "0.1"}
+paths: {}
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Pet"}
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Pet: {type: object}
+`)
+	}
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec("3.1.0"))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	require.NotContains(t, doc.Extensions, "webhooks")
+
+	schema := doc.Webhooks["newPet"].Post.RequestBody.Value.Content.Get("application/json").Schema
+	require.Equal(t, "#/components/schemas/Pet", schema.Ref)
+	require.Equal(t, doc.Components.Schemas["Pet"].Value, schema.Value)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"webhooks":{"newPet":{"post":`)
+
+	doc.Webhooks["newPet"].Post.Responses = nil
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, `invalid webhooks: webhook "newPet": invalid operation POST: value of responses must be an object`)
+
+	doc, err = loader.LoadFromData(spec("3.0.3"))
+	require.NoError(t, err)
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, `invalid webhooks: webhooks are not supported by openapi "3.0.3"`)
+}
+
+func TestFindOperationByID(t *testing.T) {
+	listPets := &Operation{OperationID: "listPets", Responses: NewResponses()}
+	createPet := &Operation{OperationID: "createPet", Responses: NewResponses()}
+	doc := &T{}
+	require.NoError(t, doc.AddOperation("/pets", http.MethodGet, listPets))
+	require.NoError(t, doc.AddOperation("/pets", http.MethodPost, createPet))
+
+	operation, path, method, err := doc.FindOperationByID("createPet")
+	require.NoError(t, err)
+	require.Equal(t, createPet, operation)
+	require.Equal(t, "/pets", path)
+	require.Equal(t, http.MethodPost, method)
+
+	_, _, _, err = doc.FindOperationByID("deletePet")
+	require.ErrorIs(t, err, ErrOperationNotFound)
+	require.EqualError(t, err, `operation not found: "deletePet"`)
+
+	require.NoError(t, doc.AddOperation("/pets/{petId}", http.MethodGet, &Operation{Responses: NewResponses()}))
+	operation, _, _, err = doc.FindOperationByID("")
+	require.ErrorIs(t, err, ErrOperationNotFound)
+	require.Nil(t, operation)
+}
+
+func TestAddOperation(t *testing.T) {
+	listPets := &Operation{OperationID: "listPets", Responses: NewResponses()}
+	listAllPets := &Operation{OperationID: "listAllPets", Responses: NewResponses()}
+	doc := &T{}
+
+	err := doc.AddOperation("/pets", http.MethodGet, listPets)
+	require.NoError(t, err)
+	err = doc.AddOperation("/pets", "get", listAllPets)
+	require.ErrorIs(t, err, ErrOperationAlreadyExists)
+	require.EqualError(t, err, "operation already exists: GET /pets")
+	require.Same(t, listPets, doc.Paths["/pets"].Get)
+
+	err = doc.AddOperation("/pets", "QUERY", listAllPets)
+	require.EqualError(t, err, `unsupported HTTP method "QUERY"`)
+	err = doc.AddOperation("/dogs", "QUERY", listAllPets)
+	require.Error(t, err)
+	require.NotContains(t, doc.Paths, "/dogs")
+
+	doc.SetOperation("/pets", "get", listAllPets)
+	require.Same(t, listAllPets, doc.Paths["/pets"].Get)
+	doc.SetOperation("/dogs", http.MethodGet, listPets)
+	require.Same(t, listPets, doc.Paths["/dogs"].Get)
+	require.Panics(t, func() { doc.SetOperation("/pets", "QUERY", listPets) })
+}
+
+func TestValidateContextDeadline(t *testing.T) {
+	schema := NewObjectSchema().WithProperty("id", NewInt64Schema())
+	responses := NewResponses()
+	responses["default"].Value.Content = NewContentWithJSONSchemaRef(&SchemaRef{Ref: "#/components/schemas/pet", Value: schema})
+	doc := &T{
+		OpenAPI:    "3.0.3",
+		Info:       &Info{Title: "MyAPI", Version: "0.1"},
+		Components: &Components{Schemas: Schemas{"pet": schema.NewRef()}},
+	}
+	require.NoError(t, doc.AddOperation("/pets", http.MethodGet, &Operation{Responses: responses}))
+	require.NoError(t, doc.Validate(context.Background()))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	for _, v := range []interface {
+		Validate(context.Context, ...ValidationOption) error
+	}{
+		doc, doc.Components, doc.Paths, doc.Paths["/pets"], doc.Paths["/pets"].Get, schema,
+	} {
+		require.ErrorIs(t, v.Validate(ctx), context.DeadlineExceeded)
+	}
+}
+
+func TestValidateCanceledMidway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	DefineStringFormatCallback("cancel-validation", func(value string) error {
+		calls++
+		cancel()
+		return nil
+	})
+	defer delete(SchemaStringFormats, "cancel-validation")
+
+	doc := &T{
+		OpenAPI:    "3.0.3",
+		Info:       &Info{Title: "MyAPI", Version: "0.1"},
+		Components: &Components{Schemas: make(Schemas)},
+	}
+	for i := 0; i < 2000; i++ {
+		name := fmt.Sprintf("schema%d", i)
+		schema := NewObjectSchema().
+			WithProperty("id", NewInt64Schema()).
+			WithProperty("name", NewStringSchema().WithFormat("cancel-validation"))
+		schema.Example = map[string]interface{}{"id": i, "name": name}
+		doc.Components.Schemas[name] = schema.NewRef()
+
+		responses := NewResponses()
+		responses["default"].Value.Content = NewContentWithJSONSchemaRef(&SchemaRef{Ref: "#/components/schemas/" + name, Value: schema})
+		require.NoError(t, doc.AddOperation(fmt.Sprintf("/resources%d", i), http.MethodGet, &Operation{Responses: responses}))
+	}
+
+	// The first example validated cancels the context: the rest of the document is skipped
+	err := doc.Validate(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, calls)
+}
+
+func BenchmarkValidate(b *testing.B) {
+	doc := &T{
+		OpenAPI: "3.0.3",
+		Info:    &Info{Title: "MyAPI", Version: "0.1"},
+		Components: &Components{
+			Schemas: make(Schemas),
+		},
+		Paths: make(Paths),
+	}
+	for i := 0; i < 500; i++ {
+		name := fmt.Sprintf("schema%d", i)
+		schema := NewObjectSchema().
+			WithProperty("id", NewInt64Schema()).
+			WithProperty("name", NewStringSchema().WithMaxLength(64)).
+			WithProperty("tags", NewArraySchema().WithItems(NewStringSchema()))
+		doc.Components.Schemas[name] = schema.NewRef()
+
+		responses := NewResponses()
+		responses["default"].Value.Content = NewContentWithJSONSchemaRef(&SchemaRef{Ref: "#/components/schemas/" + name, Value: schema})
+		require.NoError(b, doc.AddOperation(fmt.Sprintf("/resources%d", i), http.MethodGet, &Operation{Responses: responses}))
+	}
+	ctx := context.Background()
+	require.NoError(b, doc.Validate(ctx))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := doc.Validate(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTBuilders(t *testing.T) {
+	doc := NewT()
+	require.Equal(t, &T{OpenAPI: "3.0.3", Info: &Info{}, Paths: Paths{}}, doc)
+
+	info := &Info{Title: "Pets", Version: "1.0.0"}
+	paths := Paths{"/pets": &PathItem{Get: &Operation{Responses: NewResponses()}}}
+	components := &Components{Schemas: Schemas{"Pet": NewObjectSchema().NewRef()}}
+	servers := Servers{{URL: "https://example.com/api"}}
+	security := SecurityRequirements{{"apiKey": {}}}
+	tags := Tags{{Name: "pets"}}
+	externalDocs := &ExternalDocs{URL: "https://example.com/docs"}
+
+	require.Same(t, doc, doc.
+		WithOpenAPI("3.0.0").
+		WithInfo(info).
+		WithPaths(paths).
+		WithComponents(components).
+		WithServers(servers).
+		WithSecurity(security).
+		WithTags(tags).
+		WithExternalDocs(externalDocs))
+	require.Equal(t, &T{
+		OpenAPI:      "3.0.0",
+		Info:         info,
+		Paths:        paths,
+		Components:   components,
+		Servers:      servers,
+		Security:     security,
+		Tags:         tags,
+		ExternalDocs: externalDocs,
+	}, doc)
+}
@@ -13,7 +13,7 @@ import (
 // Operation represents "operation" specified by" OpenAPI/Swagger 3.0 standard.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#operation-object
 type Operation struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	// Optional tags for documentation.
 	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
@@ -180,9 +180,58 @@ func (operation *Operation) AddResponse(status int, response *Response) {
 	}
 }
 
+func (operation *Operation) WithTag(tag string) *Operation {
+	operation.Tags = append(operation.Tags, tag)
+	return operation
+}
+
+func (operation *Operation) WithSummary(value string) *Operation {
+	operation.Summary = value
+	return operation
+}
+
+func (operation *Operation) WithDescription(value string) *Operation {
+	operation.Description = value
+	return operation
+}
+
+func (operation *Operation) WithOperationID(value string) *Operation {
+	operation.OperationID = value
+	return operation
+}
+
+func (operation *Operation) WithParameter(p *Parameter) *Operation {
+	operation.AddParameter(p)
+	return operation
+}
+
+func (operation *Operation) WithRequestBody(requestBody *RequestBody) *Operation {
+	operation.RequestBody = &RequestBodyRef{Value: requestBody}
+	return operation
+}
+
+// WithResponse sets the response of the operation for the status code, see AddResponse.
+func (operation *Operation) WithResponse(status int, response *Response) *Operation {
+	operation.AddResponse(status, response)
+	return operation
+}
+
+func (operation *Operation) WithDefaultResponse(response *Response) *Operation {
+	operation.AddResponse(0, response)
+	return operation
+}
+
+func (operation *Operation) WithDeprecated(value bool) *Operation {
+	operation.Deprecated = value
+	return operation
+}
+
 // Validate returns an error if Operation does not comply with the OpenAPI spec.
 func (operation *Operation) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	if v := operation.Parameters; v != nil {
 		if err := v.Validate(ctx); err != nil {
@@ -204,6 +253,12 @@ func (operation *Operation) Validate(ctx context.Context, opts ...ValidationOpti
 		return errors.New("value of responses must be an object")
 	}
 
+	if v := operation.Security; v != nil {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("invalid security: %w", err)
+		}
+	}
+
 	if v := operation.ExternalDocs; v != nil {
 		if err := v.Validate(ctx); err != nil {
 			return fmt.Errorf("invalid external docs: %w", err)
@@ -33,6 +33,36 @@ func TestAddResponse(t *testing.T) {
 	require.NotNil(t, "status 400", operation.Responses.Get(400).Value)
 }
 
+func TestOperationBuilders(t *testing.T) {
+	param := NewQueryParameter("limit").WithSchema(NewIntegerSchema())
+	requestBody := NewRequestBody().WithJSONSchema(NewObjectSchema())
+	ok := NewResponse().WithDescription("ok")
+	fallback := NewResponse().WithDescription("unexpected error")
+
+	operation := NewOperation().
+		WithTag("pets").
+		WithTag("store").
+		WithSummary("List pets").
+		WithDescription("Lists all pets").
+		WithOperationID("listPets").
+		WithParameter(param).
+		WithRequestBody(requestBody).
+		WithResponse(200, ok).
+		WithDefaultResponse(fallback).
+		WithDeprecated(true)
+
+	require.Equal(t, []string{"pets", "store"}, operation.Tags)
+	require.Equal(t, "List pets", operation.Summary)
+	require.Equal(t, "Lists all pets", operation.Description)
+	require.Equal(t, "listPets", operation.OperationID)
+	require.Equal(t, Parameters{{Value: param}}, operation.Parameters)
+	require.Same(t, requestBody, operation.RequestBody.Value)
+	require.Same(t, ok, operation.Responses.Get(200).Value)
+	require.Same(t, fallback, operation.Responses.Default().Value)
+	require.True(t, operation.Deprecated)
+	require.NoError(t, operation.Validate(context.Background()))
+}
+
 func operationWithoutResponses() *Operation {
 	initOperation()
 	return operation
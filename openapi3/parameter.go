@@ -71,8 +71,23 @@ func (parameters Parameters) GetByInAndName(in string, name string) *Parameter {
 func (parameters Parameters) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
 
+	if err := parameters.validateUniqueness(); err != nil {
+		return err
+	}
+	for _, parameterRef := range parameters {
+		if err := parameterRef.Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (parameters Parameters) validateUniqueness() error {
 	dupes := make(map[string]struct{})
 	for _, parameterRef := range parameters {
+		if parameterRef == nil {
+			continue
+		}
 		if v := parameterRef.Value; v != nil {
 			key := v.In + ":" + v.Name
 			if _, ok := dupes[key]; ok {
@@ -80,10 +95,6 @@ func (parameters Parameters) Validate(ctx context.Context, opts ...ValidationOpt
 			}
 			dupes[key] = struct{}{}
 		}
-
-		if err := parameterRef.Validate(ctx); err != nil {
-			return err
-		}
 	}
 	return nil
 }
@@ -91,7 +102,7 @@ func (parameters Parameters) Validate(ctx context.Context, opts ...ValidationOpt
 // Parameter is specified by OpenAPI/Swagger 3.0 standard.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#parameter-object
 type Parameter struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Name            string      `json:"name,omitempty" yaml:"name,omitempty"`
 	In              string      `json:"in,omitempty" yaml:"in,omitempty"`
@@ -6,12 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 )
 
 // PathItem is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#path-item-object
 type PathItem struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Ref         string     `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Summary     string     `json:"summary,omitempty" yaml:"summary,omitempty"`
@@ -139,8 +140,10 @@ func (pathItem *PathItem) Operations() map[string]*Operation {
 	return operations
 }
 
+// GetOperation returns the operation of the path item for the HTTP method, matched case-insensitively.
+// It panics if the method is not supported.
 func (pathItem *PathItem) GetOperation(method string) *Operation {
-	switch method {
+	switch strings.ToUpper(method) {
 	case http.MethodConnect:
 		return pathItem.Connect
 	case http.MethodDelete:
@@ -164,8 +167,10 @@ func (pathItem *PathItem) GetOperation(method string) *Operation {
 	}
 }
 
-func (pathItem *PathItem) SetOperation(method string, operation *Operation) {
-	switch method {
+// SetOperation sets the operation of the path item for the HTTP method, matched case-insensitively.
+// It returns an error if the method is not supported.
+func (pathItem *PathItem) SetOperation(method string, operation *Operation) error {
+	switch strings.ToUpper(method) {
 	case http.MethodConnect:
 		pathItem.Connect = operation
 	case http.MethodDelete:
@@ -185,13 +190,98 @@ func (pathItem *PathItem) SetOperation(method string, operation *Operation) {
 	case http.MethodTrace:
 		pathItem.Trace = operation
 	default:
-		panic(fmt.Errorf("unsupported HTTP method %q", method))
+		return fmt.Errorf("unsupported HTTP method %q", method)
+	}
+	return nil
+}
+
+// MustSetOperation is like SetOperation but panics if the method is not supported.
+func (pathItem *PathItem) MustSetOperation(method string, operation *Operation) {
+	if err := pathItem.SetOperation(method, operation); err != nil {
+		panic(err)
 	}
 }
 
+func NewPathItem() *PathItem {
+	return &PathItem{}
+}
+
+func (pathItem *PathItem) WithSummary(value string) *PathItem {
+	pathItem.Summary = value
+	return pathItem
+}
+
+func (pathItem *PathItem) WithDescription(value string) *PathItem {
+	pathItem.Description = value
+	return pathItem
+}
+
+func (pathItem *PathItem) WithConnect(operation *Operation) *PathItem {
+	pathItem.Connect = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithDelete(operation *Operation) *PathItem {
+	pathItem.Delete = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithGet(operation *Operation) *PathItem {
+	pathItem.Get = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithHead(operation *Operation) *PathItem {
+	pathItem.Head = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithOptions(operation *Operation) *PathItem {
+	pathItem.Options = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithPatch(operation *Operation) *PathItem {
+	pathItem.Patch = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithPost(operation *Operation) *PathItem {
+	pathItem.Post = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithPut(operation *Operation) *PathItem {
+	pathItem.Put = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithTrace(operation *Operation) *PathItem {
+	pathItem.Trace = operation
+	return pathItem
+}
+
+func (pathItem *PathItem) WithServers(servers Servers) *PathItem {
+	pathItem.Servers = servers
+	return pathItem
+}
+
+func (pathItem *PathItem) WithParameters(parameters Parameters) *PathItem {
+	pathItem.Parameters = parameters
+	return pathItem
+}
+
 // Validate returns an error if PathItem does not comply with the OpenAPI spec.
 func (pathItem *PathItem) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Operations may override these parameters, so duplicates are only checked within each level.
+	if err := pathItem.Parameters.validateUniqueness(); err != nil {
+		return fmt.Errorf("invalid path item parameters: %w", err)
+	}
 
 	operations := pathItem.Operations()
 
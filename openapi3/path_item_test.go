@@ -0,0 +1,66 @@
+package openapi3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathItemBuilders(t *testing.T) {
+	operations := make(map[string]*Operation)
+	for _, method := range []string{
+		http.MethodConnect,
+		http.MethodDelete,
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPatch,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodTrace,
+	} {
+		operations[method] = &Operation{OperationID: method, Responses: NewResponses()}
+	}
+	servers := Servers{{URL: "https://example.com/api"}}
+	parameters := Parameters{{Value: NewPathParameter("id").WithSchema(NewStringSchema())}}
+
+	pathItem := NewPathItem().
+		WithSummary("Pets").
+		WithDescription("Manage pets").
+		WithConnect(operations[http.MethodConnect]).
+		WithDelete(operations[http.MethodDelete]).
+		WithGet(operations[http.MethodGet]).
+		WithHead(operations[http.MethodHead]).
+		WithOptions(operations[http.MethodOptions]).
+		WithPatch(operations[http.MethodPatch]).
+		WithPost(operations[http.MethodPost]).
+		WithPut(operations[http.MethodPut]).
+		WithTrace(operations[http.MethodTrace]).
+		WithServers(servers).
+		WithParameters(parameters)
+
+	require.Equal(t, "Pets", pathItem.Summary)
+	require.Equal(t, "Manage pets", pathItem.Description)
+	require.Equal(t, operations, pathItem.Operations())
+	require.Equal(t, servers, pathItem.Servers)
+	require.Equal(t, parameters, pathItem.Parameters)
+}
+
+func TestPathItemOperationCaseInsensitive(t *testing.T) {
+	operation := NewOperation()
+	pathItem := NewPathItem()
+
+	require.NoError(t, pathItem.SetOperation("get", operation))
+	require.Same(t, operation, pathItem.Get)
+	require.Same(t, operation, pathItem.GetOperation("get"))
+	require.Same(t, operation, pathItem.GetOperation("GET"))
+	require.Same(t, operation, pathItem.GetOperation("Get"))
+
+	err := pathItem.SetOperation("QUERY", operation)
+	require.EqualError(t, err, `unsupported HTTP method "QUERY"`)
+	require.PanicsWithError(t, `unsupported HTTP method "QUERY"`, func() { pathItem.MustSetOperation("QUERY", operation) })
+
+	pathItem.MustSetOperation("post", operation)
+	require.Same(t, operation, pathItem.Post)
+}
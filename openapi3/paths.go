@@ -14,6 +14,9 @@ type Paths map[string]*PathItem
 // Validate returns an error if Paths does not comply with the OpenAPI spec.
 func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	normalizedPaths := make(map[string]string, len(paths))
 
@@ -28,6 +31,12 @@ func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error
 			return fmt.Errorf("path %q does not start with a forward slash (/)", path)
 		}
 
+		if !getValidationOptions(ctx).nonStandardPathsAllowed {
+			if err := validatePathTemplate(path); err != nil {
+				return err
+			}
+		}
+
 		if pathItem == nil {
 			pathItem = &PathItem{}
 			paths[path] = pathItem
@@ -106,6 +115,12 @@ func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error
 		return err
 	}
 
+	if options := getValidationOptions(ctx); options.descriptionsRequired {
+		if err := paths.validateDescriptions(options.pathPrefix); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -169,6 +184,29 @@ func (paths Paths) Find(key string) *PathItem {
 	return nil
 }
 
+// Keys returns the sorted paths.
+func (paths Paths) Keys() []string {
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ForEach calls fn with each path and its non-nil path item, in the order of Keys.
+// It stops at and returns the first error fn returns.
+func (paths Paths) ForEach(fn func(path string, item *PathItem) error) error {
+	for _, path := range paths.Keys() {
+		if item := paths[path]; item != nil {
+			if err := fn(path, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (paths Paths) validateUniqueOperationIDs() error {
 	operationIDs := make(map[string]string)
 	for urlPath, pathItem := range paths {
@@ -193,6 +231,74 @@ func (paths Paths) validateUniqueOperationIDs() error {
 	return nil
 }
 
+// validateDescriptions reports the JSON Pointers, starting with prefix, of path items
+// and operations with an empty summary or description.
+func (paths Paths) validateDescriptions(prefix string) error {
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var me MultiError
+	check := func(location, summary, description string) {
+		if summary == "" {
+			me = append(me, fmt.Errorf("%s/summary: value must be a non-empty string", location))
+		}
+		if description == "" {
+			me = append(me, fmt.Errorf("%s/description: value must be a non-empty string", location))
+		}
+	}
+	for _, path := range keys {
+		pathItem := paths[path]
+		if pathItem == nil {
+			continue
+		}
+		location := prefix + "/paths/" + escapeJSONPointerToken(path)
+		check(location, pathItem.Summary, pathItem.Description)
+
+		operations := pathItem.Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := operations[method]
+			check(location+"/"+strings.ToLower(method), operation.Summary, operation.Description)
+		}
+	}
+	if len(me) != 0 {
+		return me
+	}
+	return nil
+}
+
+// validatePathTemplate checks the path only contains {name} placeholders,
+// rejecting router-specific syntaxes such as {id:[0-9]+} or {path*}.
+func validatePathTemplate(path string) error {
+	rest := path
+	for {
+		opening := strings.IndexAny(rest, "{}")
+		if opening < 0 {
+			return nil
+		}
+		if rest[opening] == '}' {
+			return fmt.Errorf("invalid path template %q: unbalanced braces", path)
+		}
+		rest = rest[opening+1:]
+		closing := strings.IndexAny(rest, "{}")
+		if closing < 0 || rest[closing] == '{' {
+			return fmt.Errorf("invalid path template %q: unbalanced braces", path)
+		}
+		name := rest[:closing]
+		if name == "" || strings.ContainsAny(name, "/:*()[]|\\+?^$ ") {
+			return fmt.Errorf("invalid path template %q: placeholder {%s} is not a parameter name", path, name)
+		}
+		rest = rest[closing+1:]
+	}
+}
+
 func normalizeTemplatedPath(path string) (string, uint, map[string]struct{}) {
 	if strings.IndexByte(path, '{') < 0 {
 		return path, 0, nil
@@ -2,9 +2,13 @@ package openapi3
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestPathsValidate(t *testing.T) {
@@ -75,6 +79,63 @@ paths:
 `,
 			wantErr: `operations "POST /pets" and "POST /users" have the same operation id "createPet"`,
 		},
+		{
+			name: "operation overrides path item parameter",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /pets:
+    parameters:
+    - {name: limit, in: query, schema: {type: integer}}
+    get:
+      parameters:
+      - {name: limit, in: query, schema: {type: integer, maximum: 100}}
+      responses:
+        200:
+          description: "pets"
+`,
+		},
+		{
+			name: "path item parameters are not unique",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /pets:
+    parameters:
+    - {name: limit, in: query, schema: {type: integer}}
+    - {name: limit, in: query, schema: {type: string}}
+    get:
+      responses:
+        200:
+          description: "pets"
+`,
+			wantErr: `invalid path /pets: invalid path item parameters: more than one "query" parameter has name "limit"`,
+		},
+		{
+			name: "operation parameters are not unique",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /pets:
+    get:
+      parameters:
+      - {name: limit, in: query, schema: {type: integer}}
+      - {name: limit, in: query, schema: {type: string}}
+      responses:
+        200:
+          description: "pets"
+`,
+			wantErr: `invalid path /pets: invalid operation GET: more than one "query" parameter has name "limit"`,
+		},
 	}
 
 	for i := range tests {
@@ -94,3 +155,141 @@ paths:
 		})
 	}
 }
+
+func TestPathsValidateRequireDescriptions(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /pets/{petId}:
+    summary: A pet
+    description: A pet of the store
+    parameters:
+    - {name: petId, in: path, required: true, schema: {type: string}}
+    get:
+      summary: Get a pet
+      responses:
+        200:
+          description: OK
+    delete:
+      description: Delete a pet
+      responses:
+        204:
+          description: OK
+  /store:
+    get:
+      summary: Get the store
+      description: Get the store
+      responses:
+        200:
+          description: OK
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background(), WithRequireDescriptions())
+	require.EqualError(t, err, "invalid paths: "+
+		"/paths/~1pets~1{petId}/delete/summary: value must be a non-empty string | "+
+		"/paths/~1pets~1{petId}/get/description: value must be a non-empty string | "+
+		"/paths/~1store/summary: value must be a non-empty string | "+
+		"/paths/~1store/description: value must be a non-empty string")
+
+	err = doc.Validate(context.Background(), WithRequireDescriptions(), WithSetPath("/x-apis/pets/"))
+	require.EqualError(t, err, "invalid paths: "+
+		"/x-apis/pets/paths/~1pets~1{petId}/delete/summary: value must be a non-empty string | "+
+		"/x-apis/pets/paths/~1pets~1{petId}/get/description: value must be a non-empty string | "+
+		"/x-apis/pets/paths/~1store/summary: value must be a non-empty string | "+
+		"/x-apis/pets/paths/~1store/description: value must be a non-empty string")
+}
+
+func TestPathsValidateNonStandardTemplates(t *testing.T) {
+	for path, wantErr := range map[string]string{
+		"/pets/{petId}":          "",
+		"/pets/{petId}/{petId2}": "",
+		"/pets/{id:[0-9]+}":      `invalid path template "/pets/{id:[0-9]+}": placeholder {id:[0-9]+} is not a parameter name`,
+		"/files/{path*}":         `invalid path template "/files/{path*}": placeholder {path*} is not a parameter name`,
+		"/pets/{}":               `invalid path template "/pets/{}": placeholder {} is not a parameter name`,
+		"/pets/{petId":           `invalid path template "/pets/{petId": unbalanced braces`,
+		"/pets/petId}":           `invalid path template "/pets/petId}": unbalanced braces`,
+		"/pets/{pet{Id}}":        `invalid path template "/pets/{pet{Id}}": unbalanced braces`,
+	} {
+		paths := Paths{path: &PathItem{}}
+		err := paths.Validate(context.Background())
+		if wantErr == "" {
+			require.NoError(t, err, path)
+		} else {
+			require.EqualError(t, err, wantErr, path)
+		}
+
+		err = paths.Validate(context.Background(), WithAllowNonStandardPaths())
+		require.NoError(t, err, path)
+	}
+}
+
+func TestPathsMarshalSorted(t *testing.T) {
+	paths := make(Paths)
+	for _, name := range []string{"/b", "/a/{id}", "/c", "/a"} {
+		paths[name] = &PathItem{Description: name}
+	}
+	schemas := make(Schemas)
+	for _, name := range []string{"Pet", "Error", "Owner", "Category"} {
+		schemas[name] = NewStringSchema().NewRef()
+	}
+	doc := &T{Paths: paths, Components: &Components{Schemas: schemas}}
+
+	requireOrdered := func(t *testing.T, data []byte, keys ...string) {
+		last := -1
+		for _, key := range keys {
+			i := strings.Index(string(data), key)
+			require.Greater(t, i, last, key)
+			last = i
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	requireOrdered(t, data, `"/a"`, `"/a/{id}"`, `"/b"`, `"/c"`)
+	requireOrdered(t, data, `"Category"`, `"Error"`, `"Owner"`, `"Pet"`)
+
+	data, err = yaml.Marshal(doc)
+	require.NoError(t, err)
+	requireOrdered(t, data, "/a:", "/a/{id}:", "/b:", "/c:")
+	requireOrdered(t, data, "Category:", "Error:", "Owner:", "Pet:")
+}
+
+func TestPathsForEach(t *testing.T) {
+	paths := Paths{
+		"/b":      &PathItem{Description: "b"},
+		"/a/{id}": &PathItem{Description: "a/{id}"},
+		"/nil":    nil,
+		"/a":      &PathItem{Description: "a"},
+	}
+	require.Equal(t, []string{"/a", "/a/{id}", "/b", "/nil"}, paths.Keys())
+	require.Empty(t, Paths(nil).Keys())
+
+	var visited []string
+	err := paths.ForEach(func(path string, item *PathItem) error {
+		visited = append(visited, path+"="+item.Description)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/a=a", "/a/{id}=a/{id}", "/b=b"}, visited)
+
+	errStop := errors.New("stop")
+	visited = nil
+	err = paths.ForEach(func(path string, item *PathItem) error {
+		visited = append(visited, path)
+		if path == "/a/{id}" {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, []string{"/a", "/a/{id}"}, visited)
+}
@@ -74,6 +74,23 @@ func (x *CallbackRef) Validate(ctx context.Context, opts ...ValidationOption) er
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Callback:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -150,6 +167,23 @@ func (x *ExampleRef) Validate(ctx context.Context, opts ...ValidationOption) err
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Example:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -226,6 +260,23 @@ func (x *HeaderRef) Validate(ctx context.Context, opts ...ValidationOption) erro
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Header:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -302,6 +353,23 @@ func (x *LinkRef) Validate(ctx context.Context, opts ...ValidationOption) error
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Link:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -378,6 +446,23 @@ func (x *ParameterRef) Validate(ctx context.Context, opts ...ValidationOption) e
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Parameter:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -454,6 +539,23 @@ func (x *RequestBodyRef) Validate(ctx context.Context, opts ...ValidationOption)
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *RequestBody:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -530,6 +632,23 @@ func (x *ResponseRef) Validate(ctx context.Context, opts ...ValidationOption) er
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Response:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -606,6 +725,23 @@ func (x *SchemaRef) Validate(ctx context.Context, opts ...ValidationOption) erro
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *Schema:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -682,6 +818,23 @@ func (x *SecuritySchemeRef) Validate(ctx context.Context, opts ...ValidationOpti
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *SecurityScheme:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
@@ -1,6 +1,8 @@
 package openapi3
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -180,6 +182,15 @@ paths:
         - write:pets
         - read:pets
 components:
+  securitySchemes:
+    petstore_auth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: http://example.org/api/oauth/dialog
+          scopes:
+            write:pets: modify pets in your account
+            read:pets: read your pets
   schemas:
     Pet:
       type: object
@@ -290,7 +301,7 @@ components:
 	require.NoError(t, err)
 	require.IsType(t, &Schema{}, v)
 	require.Equal(t, reflect.Ptr, kind)
-	require.Equal(t, "integer", v.(*Schema).Type)
+	require.Equal(t, SchemaTypes{"integer"}, v.(*Schema).Type)
 
 	ptr, err = jsonpointer.New("/components/schemas/OneOfTest/oneOf/0")
 	require.NoError(t, err)
@@ -298,7 +309,7 @@ components:
 	require.NoError(t, err)
 	require.IsType(t, &Schema{}, v)
 	require.Equal(t, reflect.Ptr, kind)
-	require.Equal(t, "string", v.(*Schema).Type)
+	require.Equal(t, SchemaTypes{"string"}, v.(*Schema).Type)
 
 	ptr, err = jsonpointer.New("/components/schemas/OneOfTest/oneOf/1")
 	require.NoError(t, err)
@@ -306,7 +317,7 @@ components:
 	require.NoError(t, err)
 	require.IsType(t, &Schema{}, v)
 	require.Equal(t, reflect.Ptr, kind)
-	require.Equal(t, "integer", v.(*Schema).Type)
+	require.Equal(t, SchemaTypes{"integer"}, v.(*Schema).Type)
 
 	ptr, err = jsonpointer.New("/components/schemas/OneOfTest/oneOf/5")
 	require.NoError(t, err)
@@ -318,3 +329,48 @@ components:
 	_, _, err = ptr.Get(doc)
 	require.Error(t, err)
 }
+
+func TestValidateWithRefResolver(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: MyAPI
+  version: 0.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'https://registry.example.com/schemas/Pet'
+`)
+	// Unmarshal only, the registry is not reachable by the loader.
+	doc := &T{}
+	require.NoError(t, unmarshal(spec, doc))
+
+	ctx := context.Background()
+	err := doc.Validate(ctx)
+	require.EqualError(t, err, `found unresolved ref: "https://registry.example.com/schemas/Pet" at /paths/~1pets/get/responses/200/content/application~1json/schema`)
+
+	registry := map[string]interface{}{
+		"https://registry.example.com/schemas/Pet": NewObjectSchema(),
+	}
+	resolver := func(ref string) (interface{}, error) {
+		if v, ok := registry[ref]; ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("ref %q not in registry", ref)
+	}
+	err = doc.Validate(ctx, WithRefResolver(resolver))
+	require.NoError(t, err)
+
+	registry["https://registry.example.com/schemas/Pet"] = &Schema{Type: SchemaTypes{"pet"}}
+	err = doc.Validate(ctx, WithRefResolver(resolver))
+	require.EqualError(t, err, `invalid paths: invalid path /pets: invalid operation GET: unsupported 'type' value "pet"`)
+
+	err = doc.Validate(ctx, WithRefResolver(func(string) (interface{}, error) { return nil, nil }))
+	require.NoError(t, err)
+}
@@ -44,7 +44,11 @@ func (responses Responses) Validate(ctx context.Context, opts ...ValidationOptio
 		keys = append(keys, key)
 	}
 	sort.Strings(keys)
+	informationalDisallowed := getValidationOptions(ctx).informationalResponsesDisallowed
 	for _, key := range keys {
+		if informationalDisallowed && len(key) == 3 && key[0] == '1' {
+			return fmt.Errorf("response %q is informational: 1xx responses are not final", key)
+		}
 		v := responses[key]
 		if err := v.Validate(ctx); err != nil {
 			return err
@@ -69,7 +73,7 @@ func (responses Responses) JSONLookup(token string) (interface{}, error) {
 // Response is specified by OpenAPI/Swagger 3.0 standard.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#response-object
 type Response struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
 	Headers     Headers `json:"headers,omitempty" yaml:"headers,omitempty"`
@@ -91,6 +95,33 @@ func (response *Response) WithContent(content Content) *Response {
 	return response
 }
 
+// WithMediaType sets the media type of the response content for contentType.
+func (response *Response) WithMediaType(contentType string, mediaType *MediaType) *Response {
+	if response.Content == nil {
+		response.Content = make(Content)
+	}
+	response.Content[contentType] = mediaType
+	return response
+}
+
+// WithHeader sets the response header with the given name.
+func (response *Response) WithHeader(name string, header *Header) *Response {
+	if response.Headers == nil {
+		response.Headers = make(Headers)
+	}
+	response.Headers[name] = &HeaderRef{Value: header}
+	return response
+}
+
+// AddLink sets the response link with the given name.
+func (response *Response) AddLink(name string, link *Link) *Response {
+	if response.Links == nil {
+		response.Links = make(Links)
+	}
+	response.Links[name] = &LinkRef{Value: link}
+	return response
+}
+
 func (response *Response) WithJSONSchema(schema *Schema) *Response {
 	response.Content = NewContentWithJSONSchema(schema)
 	return response
@@ -0,0 +1,39 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseBuilders(t *testing.T) {
+	header := &Header{Parameter: Parameter{Schema: NewStringSchema().NewRef()}}
+	link := &Link{OperationID: "getPet"}
+	mediaType := NewMediaType().WithSchema(NewObjectSchema())
+
+	response := NewResponse().
+		WithDescription("a pet").
+		WithMediaType("application/json", mediaType).
+		WithHeader("X-Rate-Limit", header).
+		AddLink("GetPet", link)
+
+	require.Equal(t, "a pet", *response.Description)
+	require.Same(t, mediaType, response.Content.Get("application/json"))
+	require.Same(t, header, response.Headers["X-Rate-Limit"].Value)
+	require.Same(t, link, response.Links["GetPet"].Value)
+	require.NoError(t, response.Validate(context.Background()))
+}
+
+func TestResponsesValidateStrict1xxHandling(t *testing.T) {
+	ok := &ResponseRef{Value: NewResponse().WithDescription("OK")}
+	for _, code := range []string{"100", "101", "1XX"} {
+		responses := Responses{"200": ok, code: &ResponseRef{Value: NewResponse().WithDescription("Continue")}}
+		require.NoError(t, responses.Validate(context.Background()))
+
+		err := responses.Validate(context.Background(), WithStrict1xxHandling())
+		require.EqualError(t, err, `response "`+code+`" is informational: 1xx responses are not final`)
+	}
+
+	require.NoError(t, Responses{"200": ok, "default": ok}.Validate(context.Background(), WithStrict1xxHandling()))
+}
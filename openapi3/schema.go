@@ -14,7 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/mohae/deepcopy"
@@ -60,6 +60,21 @@ func NewSchemaRef(ref string, value *Schema) *SchemaRef {
 	}
 }
 
+// WithRef makes the SchemaRef a reference to ref, e.g. "#/components/schemas/Pet".
+// The value is cleared: it is set by the Loader when the reference is resolved.
+func (x *SchemaRef) WithRef(ref string) *SchemaRef {
+	x.Ref = ref
+	x.Value = nil
+	return x
+}
+
+// WithValue makes the SchemaRef an inline schema, clearing any reference.
+func (x *SchemaRef) WithValue(value *Schema) *SchemaRef {
+	x.Ref = ""
+	x.Value = value
+	return x
+}
+
 type Schemas map[string]*SchemaRef
 
 var _ jsonpointer.JSONPointable = (*Schemas)(nil)
@@ -103,13 +118,13 @@ func (s SchemaRefs) JSONLookup(token string) (interface{}, error) {
 // Schema is specified by OpenAPI/Swagger 3.0 standard.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#schema-object
 type Schema struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	OneOf        SchemaRefs    `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
 	AnyOf        SchemaRefs    `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
 	AllOf        SchemaRefs    `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 	Not          *SchemaRef    `json:"not,omitempty" yaml:"not,omitempty"`
-	Type         string        `json:"type,omitempty" yaml:"type,omitempty"`
+	Type         SchemaTypes   `json:"type,omitempty" yaml:"type,omitempty"`
 	Title        string        `json:"title,omitempty" yaml:"title,omitempty"`
 	Format       string        `json:"format,omitempty" yaml:"format,omitempty"`
 	Description  string        `json:"description,omitempty" yaml:"description,omitempty"`
@@ -118,6 +133,11 @@ type Schema struct {
 	Example      interface{}   `json:"example,omitempty" yaml:"example,omitempty"`
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 
+	// JSON Schema 2020-12 (OpenAPI 3.1) anchors
+	Anchor        string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
+	DynamicRef    string `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
+
 	// Array-related, here for struct compactness
 	UniqueItems bool `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
 	// Number-related, here for struct compactness
@@ -135,6 +155,9 @@ type Schema struct {
 	Min        *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
 	Max        *float64 `json:"maximum,omitempty" yaml:"maximum,omitempty"`
 	MultipleOf *float64 `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	// OpenAPI 3.1 numeric forms of exclusiveMinimum and exclusiveMaximum
+	ExclusiveMinValue *float64 `json:"-" yaml:"-"`
+	ExclusiveMaxValue *float64 `json:"-" yaml:"-"`
 
 	// String
 	MinLength uint64  `json:"minLength,omitempty" yaml:"minLength,omitempty"`
@@ -250,6 +273,17 @@ func (schema Schema) MarshalJSON() ([]byte, error) {
 		m["externalDocs"] = x
 	}
 
+	// JSON Schema 2020-12 anchors
+	if x := schema.Anchor; x != "" {
+		m["$anchor"] = x
+	}
+	if x := schema.DynamicRef; x != "" {
+		m["$dynamicRef"] = x
+	}
+	if x := schema.DynamicAnchor; x != "" {
+		m["$dynamicAnchor"] = x
+	}
+
 	// Array-related
 	if x := schema.UniqueItems; x {
 		m["uniqueItems"] = x
@@ -291,6 +325,12 @@ func (schema Schema) MarshalJSON() ([]byte, error) {
 	if x := schema.MultipleOf; x != nil {
 		m["multipleOf"] = x
 	}
+	if x := schema.ExclusiveMinValue; x != nil {
+		m["exclusiveMinimum"] = x
+	}
+	if x := schema.ExclusiveMaxValue; x != nil {
+		m["exclusiveMaximum"] = x
+	}
 
 	// String
 	if x := schema.MinLength; x != 0 {
@@ -340,10 +380,31 @@ func (schema Schema) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON sets Schema to a copy of data.
 func (schema *Schema) UnmarshalJSON(data []byte) error {
 	type SchemaBis Schema
-	var x SchemaBis
-	if err := json.Unmarshal(data, &x); err != nil {
+	var bounds struct {
+		SchemaBis
+		// Either booleans (OpenAPI 3.0) or numbers (OpenAPI 3.1)
+		ExclusiveMin json.RawMessage `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+		ExclusiveMax json.RawMessage `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	}
+	// Numbers are decoded as json.Number so that integers beyond float64 precision
+	// (e.g. in enum or default) are kept intact.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&bounds); err != nil {
 		return err
 	}
+	x := bounds.SchemaBis
+	for i, v := range x.Enum {
+		x.Enum[i] = preciseNumbers(v)
+	}
+	x.Default = preciseNumbers(x.Default)
+	x.Example = preciseNumbers(x.Example)
+	if err := unmarshalExclusiveBound(bounds.ExclusiveMin, &x.ExclusiveMin, &x.ExclusiveMinValue); err != nil {
+		return fmt.Errorf("invalid exclusiveMinimum: %w", err)
+	}
+	if err := unmarshalExclusiveBound(bounds.ExclusiveMax, &x.ExclusiveMax, &x.ExclusiveMaxValue); err != nil {
+		return fmt.Errorf("invalid exclusiveMaximum: %w", err)
+	}
 	_ = json.Unmarshal(data, &x.Extensions)
 
 	delete(x.Extensions, "oneOf")
@@ -359,6 +420,11 @@ func (schema *Schema) UnmarshalJSON(data []byte) error {
 	delete(x.Extensions, "example")
 	delete(x.Extensions, "externalDocs")
 
+	// JSON Schema 2020-12 anchors
+	delete(x.Extensions, "$anchor")
+	delete(x.Extensions, "$dynamicRef")
+	delete(x.Extensions, "$dynamicAnchor")
+
 	// Array-related
 	delete(x.Extensions, "uniqueItems")
 	// Number-related
@@ -406,6 +472,123 @@ func (schema *Schema) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// preciseNumbers replaces the json.Number values of v with float64 ones,
+// unless they are integers that a float64 cannot represent exactly.
+func preciseNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			if f := float64(i); f >= -(1<<63) && f < 1<<63 && int64(f) == i {
+				return f
+			}
+			return v
+		}
+		if strings.ContainsAny(v.String(), ".eE") {
+			if f, err := v.Float64(); err == nil {
+				return f
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = preciseNumbers(item)
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = preciseNumbers(item)
+		}
+	}
+	return v
+}
+
+// goNumberToFloat64 converts Go numeric types to float64, so that enum values compare
+// equal whatever their Go type.
+func goNumberToFloat64(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// jsonNumberEquals reports whether the number n, kept as a json.Number by preciseNumbers,
+// equals value: exactly for integers and json.Number values, at float64 precision for floats.
+func jsonNumberEquals(n json.Number, value interface{}) bool {
+	var x *big.Rat
+	switch value := value.(type) {
+	case json.Number:
+		if value == n {
+			return true
+		}
+		var ok bool
+		if x, ok = new(big.Rat).SetString(value.String()); !ok {
+			return false
+		}
+	case float32:
+		f, err := n.Float64()
+		return err == nil && f == float64(value)
+	case float64:
+		f, err := n.Float64()
+		return err == nil && f == value
+	case int:
+		x = new(big.Rat).SetInt64(int64(value))
+	case int8:
+		x = new(big.Rat).SetInt64(int64(value))
+	case int16:
+		x = new(big.Rat).SetInt64(int64(value))
+	case int32:
+		x = new(big.Rat).SetInt64(int64(value))
+	case int64:
+		x = new(big.Rat).SetInt64(value)
+	case uint:
+		x = new(big.Rat).SetUint64(uint64(value))
+	case uint8:
+		x = new(big.Rat).SetUint64(uint64(value))
+	case uint16:
+		x = new(big.Rat).SetUint64(uint64(value))
+	case uint32:
+		x = new(big.Rat).SetUint64(uint64(value))
+	case uint64:
+		x = new(big.Rat).SetUint64(value)
+	default:
+		return false
+	}
+	y, ok := new(big.Rat).SetString(n.String())
+	return ok && x.Cmp(y) == 0
+}
+
+func unmarshalExclusiveBound(data json.RawMessage, exclusive *bool, value **float64) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, exclusive); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, value)
+}
+
 // JSONLookup implements https://pkg.go.dev/github.com/go-openapi/jsonpointer#JSONPointable
 func (schema Schema) JSONLookup(token string) (interface{}, error) {
 	switch token {
@@ -543,78 +726,80 @@ func NewAllOfSchema(schemas ...*Schema) *Schema {
 
 func NewBoolSchema() *Schema {
 	return &Schema{
-		Type: TypeBoolean,
+		Type: SchemaTypes{TypeBoolean},
 	}
 }
 
 func NewFloat64Schema() *Schema {
 	return &Schema{
-		Type: TypeNumber,
+		Type: SchemaTypes{TypeNumber},
 	}
 }
 
 func NewIntegerSchema() *Schema {
 	return &Schema{
-		Type: TypeInteger,
+		Type: SchemaTypes{TypeInteger},
 	}
 }
 
 func NewInt32Schema() *Schema {
 	return &Schema{
-		Type:   TypeInteger,
+		Type:   SchemaTypes{TypeInteger},
 		Format: "int32",
 	}
 }
 
 func NewInt64Schema() *Schema {
 	return &Schema{
-		Type:   TypeInteger,
+		Type:   SchemaTypes{TypeInteger},
 		Format: "int64",
 	}
 }
 
 func NewStringSchema() *Schema {
 	return &Schema{
-		Type: TypeString,
+		Type: SchemaTypes{TypeString},
 	}
 }
 
 func NewDateTimeSchema() *Schema {
 	return &Schema{
-		Type:   TypeString,
+		Type:   SchemaTypes{TypeString},
 		Format: "date-time",
 	}
 }
 
 func NewUUIDSchema() *Schema {
 	return &Schema{
-		Type:   TypeString,
+		Type:   SchemaTypes{TypeString},
 		Format: "uuid",
 	}
 }
 
 func NewBytesSchema() *Schema {
 	return &Schema{
-		Type:   TypeString,
+		Type:   SchemaTypes{TypeString},
 		Format: "byte",
 	}
 }
 
 func NewArraySchema() *Schema {
 	return &Schema{
-		Type: TypeArray,
+		Type: SchemaTypes{TypeArray},
 	}
 }
 
 func NewObjectSchema() *Schema {
 	return &Schema{
-		Type:       TypeObject,
+		Type:       SchemaTypes{TypeObject},
 		Properties: make(Schemas),
 	}
 }
 
-func (schema *Schema) WithNullable() *Schema {
-	schema.Nullable = true
+// WithNullable sets whether null values are allowed, with `nullable: true` in OpenAPI 3.0.
+// T.MarshalJSON encodes nullable schemas of OpenAPI 3.1 documents with a "null" type instead.
+func (schema *Schema) WithNullable(nullable bool) *Schema {
+	schema.Nullable = nullable
 	return schema
 }
 
@@ -638,6 +823,26 @@ func (schema *Schema) WithExclusiveMax(value bool) *Schema {
 	return schema
 }
 
+// WithMinimum sets the lower bound of the schema, which excludes value when exclusive is true.
+// The bound is set in the OpenAPI 3.0 form (minimum and a boolean exclusiveMinimum),
+// replacing any OpenAPI 3.1 numeric exclusiveMinimum (ExclusiveMinValue).
+func (schema *Schema) WithMinimum(value float64, exclusive bool) *Schema {
+	schema.Min = &value
+	schema.ExclusiveMin = exclusive
+	schema.ExclusiveMinValue = nil
+	return schema
+}
+
+// WithMaximum sets the upper bound of the schema, which excludes value when exclusive is true.
+// The bound is set in the OpenAPI 3.0 form (maximum and a boolean exclusiveMaximum),
+// replacing any OpenAPI 3.1 numeric exclusiveMaximum (ExclusiveMaxValue).
+func (schema *Schema) WithMaximum(value float64, exclusive bool) *Schema {
+	schema.Max = &value
+	schema.ExclusiveMax = exclusive
+	schema.ExclusiveMaxValue = nil
+	return schema
+}
+
 func (schema *Schema) WithEnum(values ...interface{}) *Schema {
 	schema.Enum = values
 	return schema
@@ -692,9 +897,14 @@ func (schema *Schema) WithMaxLengthDecodedBase64(i int64) *Schema {
 	return schema
 }
 
-func (schema *Schema) WithPattern(pattern string) *Schema {
+// WithPattern sets the pattern, returning an error if it is not a valid regular expression.
+// The compiled regular expression is cached so values are matched without recompiling it.
+func (schema *Schema) WithPattern(pattern string) (*Schema, error) {
 	schema.Pattern = pattern
-	return schema
+	if _, err := schema.compilePattern(); err != nil {
+		return schema, err
+	}
+	return schema, nil
 }
 
 func (schema *Schema) WithItems(value *Schema) *Schema {
@@ -770,6 +980,19 @@ func (schema *Schema) WithoutAdditionalProperties() *Schema {
 	return schema
 }
 
+func (schema *Schema) WithAdditionalPropertiesAllowed(allowed bool) *Schema {
+	schema.AdditionalProperties = AdditionalProperties{Has: BoolPtr(allowed)}
+	return schema
+}
+
+func (schema *Schema) WithDiscriminator(propertyName string, mapping map[string]string) *Schema {
+	schema.Discriminator = &Discriminator{
+		PropertyName: propertyName,
+		Mapping:      mapping,
+	}
+	return schema
+}
+
 func (schema *Schema) WithAdditionalProperties(v *Schema) *Schema {
 	schema.AdditionalProperties = AdditionalProperties{}
 	if v != nil {
@@ -780,14 +1003,19 @@ func (schema *Schema) WithAdditionalProperties(v *Schema) *Schema {
 
 // IsEmpty tells whether schema is equivalent to the empty schema `{}`.
 func (schema *Schema) IsEmpty() bool {
-	if schema.Type != "" || schema.Format != "" || len(schema.Enum) != 0 ||
+	if schema == nil {
+		// The value of an unresolved reference is unknown.
+		return false
+	}
+	if len(schema.Type) != 0 || schema.Format != "" || len(schema.Enum) != 0 ||
 		schema.UniqueItems || schema.ExclusiveMin || schema.ExclusiveMax ||
 		schema.Nullable || schema.ReadOnly || schema.WriteOnly || schema.AllowEmptyValue ||
 		schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil ||
+		schema.ExclusiveMinValue != nil || schema.ExclusiveMaxValue != nil ||
 		schema.MinLength != 0 || schema.MaxLength != nil || schema.Pattern != "" ||
 		schema.MinItems != 0 || schema.MaxItems != nil ||
 		len(schema.Required) != 0 ||
-		schema.MinProps != 0 || schema.MaxProps != nil {
+		schema.MinProps != 0 || schema.MaxProps != nil || schema.DynamicRef != "" {
 		return false
 	}
 	if n := schema.Not; n != nil && !n.Value.IsEmpty() {
@@ -834,6 +1062,10 @@ func (schema *Schema) Validate(ctx context.Context, opts ...ValidationOption) er
 
 // returns the updated stack and an error if Schema does not comply with the OpenAPI spec.
 func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema, error) {
+	// Validating large documents takes a while, stop as soon as the context is done
+	if err := ctx.Err(); err != nil {
+		return stack, err
+	}
 	validationOpts := getValidationOptions(ctx)
 
 	for _, existing := range stack {
@@ -895,64 +1127,16 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema,
 		}
 	}
 
-	schemaType := schema.Type
-	switch schemaType {
-	case "":
-	case TypeBoolean:
-	case TypeNumber:
-		if format := schema.Format; len(format) > 0 {
-			switch format {
-			case "float", "double":
-			default:
-				if validationOpts.schemaFormatValidationEnabled {
-					return stack, unsupportedFormat(format)
-				}
-			}
-		}
-	case TypeInteger:
-		if format := schema.Format; len(format) > 0 {
-			switch format {
-			case "int32", "int64":
-			default:
-				if validationOpts.schemaFormatValidationEnabled {
-					return stack, unsupportedFormat(format)
-				}
-			}
+	if discriminator := schema.Discriminator; discriminator != nil {
+		if err := discriminator.validateFor(ctx, schema); err != nil {
+			return stack, fmt.Errorf("invalid discriminator: %w", err)
 		}
-	case TypeString:
-		if format := schema.Format; len(format) > 0 {
-			switch format {
-			// Supported by OpenAPIv3.0.3:
-			// https://spec.openapis.org/oas/v3.0.3
-			case "byte", "binary", "date", "date-time", "password":
-			// In JSON Draft-07 (not validated yet though):
-			// https://json-schema.org/draft-07/json-schema-release-notes.html#formats
-			case "iri", "iri-reference", "uri-template", "idn-email", "idn-hostname":
-			case "json-pointer", "relative-json-pointer", "regex", "time":
-			// In JSON Draft 2019-09 (not validated yet though):
-			// https://json-schema.org/draft/2019-09/release-notes.html#format-vocabulary
-			case "duration", "uuid":
-			// Defined in some other specification
-			case "email", "hostname", "ipv4", "ipv6", "uri", "uri-reference":
-			default:
-				// Try to check for custom defined formats
-				if _, ok := SchemaStringFormats[format]; !ok && validationOpts.schemaFormatValidationEnabled {
-					return stack, unsupportedFormat(format)
-				}
-			}
-		}
-		if !validationOpts.schemaPatternValidationDisabled && schema.Pattern != "" {
-			if _, err := schema.compilePattern(); err != nil {
-				return stack, err
-			}
-		}
-	case TypeArray:
-		if schema.Items == nil {
-			return stack, errors.New("when schema type is 'array', schema 'items' must be non-null")
+	}
+
+	for _, schemaType := range schema.Type {
+		if err := schema.validateType(ctx, schemaType); err != nil {
+			return stack, err
 		}
-	case TypeObject:
-	default:
-		return stack, fmt.Errorf("unsupported 'type' value %q", schemaType)
 	}
 
 	if ref := schema.Items; ref != nil {
@@ -963,7 +1147,7 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema,
 
 		var err error
 		if stack, err = v.validate(ctx, stack); err != nil {
-			return stack, err
+			return stack, fmt.Errorf("invalid items: %w", err)
 		}
 	}
 
@@ -981,7 +1165,7 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema,
 
 		var err error
 		if stack, err = v.validate(ctx, stack); err != nil {
-			return stack, err
+			return stack, fmt.Errorf("invalid property %q: %w", name, err)
 		}
 	}
 
@@ -996,7 +1180,7 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema,
 
 		var err error
 		if stack, err = v.validate(ctx, stack); err != nil {
-			return stack, err
+			return stack, fmt.Errorf("invalid additionalProperties: %w", err)
 		}
 	}
 
@@ -1007,8 +1191,12 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema,
 	}
 
 	if v := schema.Default; v != nil && !validationOpts.schemaDefaultsValidationDisabled {
-		if err := schema.VisitJSON(v); err != nil {
-			return stack, fmt.Errorf("invalid default: %w", err)
+		var opts []SchemaValidationOption
+		if validationOpts.schemaFormatAssertionsDisabled {
+			opts = append(opts, DisableFormatAssertions())
+		}
+		if err := schema.VisitJSON(v, opts...); err != nil {
+			return stack, fmt.Errorf("invalid default: %w", prefixSchemaErrors(ctx, err))
 		}
 	}
 
@@ -1021,6 +1209,71 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) ([]*Schema,
 	return stack, validateExtensions(ctx, schema.Extensions)
 }
 
+// validateType validates the fields of schema depending on one of its types.
+func (schema *Schema) validateType(ctx context.Context, schemaType string) error {
+	validationOpts := getValidationOptions(ctx)
+	formatValidationEnabled := validationOpts.schemaFormatValidationEnabled && !validationOpts.schemaFormatAssertionsDisabled
+
+	switch schemaType {
+	case TypeBoolean, TypeNull:
+	case TypeNumber:
+		if format := schema.Format; len(format) > 0 {
+			switch format {
+			case "float", "double":
+			default:
+				if formatValidationEnabled {
+					return unsupportedFormat(format)
+				}
+			}
+		}
+	case TypeInteger:
+		if format := schema.Format; len(format) > 0 {
+			switch format {
+			case "int32", "int64":
+			default:
+				if formatValidationEnabled {
+					return unsupportedFormat(format)
+				}
+			}
+		}
+	case TypeString:
+		if format := schema.Format; len(format) > 0 {
+			switch format {
+			// Supported by OpenAPIv3.0.3:
+			// https://spec.openapis.org/oas/v3.0.3
+			case "byte", "binary", "date", "date-time", "password":
+			// In JSON Draft-07 (not validated yet though):
+			// https://json-schema.org/draft-07/json-schema-release-notes.html#formats
+			case "iri", "iri-reference", "uri-template", "idn-email", "idn-hostname":
+			case "json-pointer", "relative-json-pointer", "regex", "time":
+			// In JSON Draft 2019-09 (not validated yet though):
+			// https://json-schema.org/draft/2019-09/release-notes.html#format-vocabulary
+			case "duration", "uuid":
+			// Defined in some other specification
+			case "email", "hostname", "ipv4", "ipv6", "uri", "uri-reference":
+			default:
+				// Try to check for custom defined formats
+				if _, ok := SchemaStringFormats[format]; !ok && formatValidationEnabled {
+					return unsupportedFormat(format)
+				}
+			}
+		}
+		if !validationOpts.schemaPatternValidationDisabled && schema.Pattern != "" {
+			if _, err := schema.compilePattern(); err != nil {
+				return err
+			}
+		}
+	case TypeArray:
+		if schema.Items == nil {
+			return errors.New("when schema type is 'array', schema 'items' must be non-null")
+		}
+	case TypeObject:
+	default:
+		return fmt.Errorf("unsupported 'type' value %q", schemaType)
+	}
+	return nil
+}
+
 func (schema *Schema) IsMatching(value interface{}) bool {
 	settings := newSchemaValidationSettings(FailFast())
 	return schema.visitJSON(settings, value) == nil
@@ -1053,13 +1306,37 @@ func (schema *Schema) IsMatchingJSONObject(value map[string]interface{}) bool {
 
 func (schema *Schema) VisitJSON(value interface{}, opts ...SchemaValidationOption) error {
 	settings := newSchemaValidationSettings(opts...)
-	return schema.visitJSON(settings, value)
+	if settings.coerce {
+		value = schema.coerce(value)
+	}
+	if settings.coercedValue != nil {
+		*settings.coercedValue = value
+	}
+	err := schema.visitJSON(settings, value)
+	if ctx := settings.ctx; err != nil && ctx != nil && ctx.Err() != nil {
+		// Errors of aborted sub-validations may have been discarded or aggregated
+		return ctx.Err()
+	}
+	return err
 }
 
 func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interface{}) (err error) {
+	if ctx := settings.ctx; ctx != nil {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+	}
+
 	switch value := value.(type) {
 	case nil:
 		return schema.visitJSONNull(settings)
+	case float32:
+		if math.IsNaN(float64(value)) {
+			return ErrSchemaInputNaN
+		}
+		if math.IsInf(float64(value), 0) {
+			return ErrSchemaInputInf
+		}
 	case float64:
 		if math.IsNaN(value) {
 			return ErrSchemaInputNaN
@@ -1069,9 +1346,15 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 		}
 	}
 
+	settings.dynamicScope = append(settings.dynamicScope, schema)
+	defer func() { settings.dynamicScope = settings.dynamicScope[:len(settings.dynamicScope)-1] }()
+
 	if schema.IsEmpty() {
 		return
 	}
+	if err = schema.visitDynamicRef(settings, value); err != nil {
+		return
+	}
 	if err = schema.visitSetOperations(settings, value); err != nil {
 		return
 	}
@@ -1094,10 +1377,26 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 		return schema.visitJSONNumber(settings, valueFloat64)
 	case int:
 		return schema.visitJSONNumber(settings, float64(value))
+	case int8:
+		return schema.visitJSONNumber(settings, float64(value))
+	case int16:
+		return schema.visitJSONNumber(settings, float64(value))
 	case int32:
 		return schema.visitJSONNumber(settings, float64(value))
 	case int64:
 		return schema.visitJSONNumber(settings, float64(value))
+	case uint:
+		return schema.visitJSONNumber(settings, float64(value))
+	case uint8:
+		return schema.visitJSONNumber(settings, float64(value))
+	case uint16:
+		return schema.visitJSONNumber(settings, float64(value))
+	case uint32:
+		return schema.visitJSONNumber(settings, float64(value))
+	case uint64:
+		return schema.visitJSONNumber(settings, float64(value))
+	case float32:
+		return schema.visitJSONNumber(settings, float64(value))
 	case float64:
 		return schema.visitJSONNumber(settings, value)
 	case string:
@@ -1137,9 +1436,82 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 	}
 }
 
+// visitDynamicRef validates value against the schema that $dynamicRef resolves to:
+// the outermost schema of the dynamic scope declaring the matching $dynamicAnchor.
+// Only plain name fragments such as "#node" are supported.
+func (schema *Schema) visitDynamicRef(settings *schemaValidationSettings, value interface{}) error {
+	ref := schema.DynamicRef
+	if ref == "" {
+		return nil
+	}
+	var target *Schema
+	if name := strings.TrimPrefix(ref, "#"); name != ref && name != "" {
+		for _, scope := range settings.dynamicScope {
+			target = scope.findSubschema(func(s *Schema) bool { return s.DynamicAnchor == name }, make(map[*Schema]struct{}))
+			if target != nil {
+				break
+			}
+		}
+	}
+	if target == nil {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           "$dynamicRef",
+			Reason:                fmt.Sprintf("unresolved $dynamicRef %q", ref),
+			customizeMessageError: settings.customizeMessageError,
+		}
+	}
+	return target.visitJSON(settings, value)
+}
+
+// findSubschema returns the first schema matching among schema and its subschemas,
+// not following references.
+func (schema *Schema) findSubschema(match func(*Schema) bool, visited map[*Schema]struct{}) *Schema {
+	if schema == nil {
+		return nil
+	}
+	if _, ok := visited[schema]; ok {
+		return nil
+	}
+	visited[schema] = struct{}{}
+	if match(schema) {
+		return schema
+	}
+
+	subschemas := make(SchemaRefs, 0, 3+len(schema.Properties)+len(schema.AllOf)+len(schema.AnyOf)+len(schema.OneOf))
+	subschemas = append(subschemas, schema.Items, schema.AdditionalProperties.Schema, schema.Not)
+	properties := schemaNames(schema.Properties)
+	sort.Strings(properties)
+	for _, property := range properties {
+		subschemas = append(subschemas, schema.Properties[property])
+	}
+	subschemas = append(subschemas, schema.AllOf...)
+	subschemas = append(subschemas, schema.AnyOf...)
+	subschemas = append(subschemas, schema.OneOf...)
+	for _, subschema := range subschemas {
+		if subschema == nil || subschema.Ref != "" {
+			continue
+		}
+		if found := subschema.Value.findSubschema(match, visited); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, value interface{}) (err error) {
 	if enum := schema.Enum; len(enum) != 0 {
 		for _, v := range enum {
+			if n, ok := v.(json.Number); ok {
+				if jsonNumberEquals(n, value) {
+					return
+				}
+				continue
+			}
 			switch c := value.(type) {
 			case json.Number:
 				var f float64
@@ -1150,6 +1522,12 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 					return
 				}
 			default:
+				if f, ok := goNumberToFloat64(value); ok {
+					if g, ok := goNumberToFloat64(v); ok && f == g {
+						return
+					}
+					continue
+				}
 				if reflect.DeepEqual(v, value) {
 					return
 				}
@@ -1243,7 +1621,7 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 			}
 
 			if err := v.visitJSON(settings, tempValue); err != nil {
-				validationErrors = append(validationErrors, err)
+				validationErrors = append(validationErrors, markSchemaErrorSchemaKeys(err, "oneOf", strconv.Itoa(idx)))
 				continue
 			}
 
@@ -1315,7 +1693,7 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 		_ = v[matchedAnyOfIdx].Value.visitJSON(settings, value)
 	}
 
-	for _, item := range schema.AllOf {
+	for idx, item := range schema.AllOf {
 		v := item.Value
 		if v == nil {
 			return foundUnresolvedRef(item.Ref)
@@ -1329,7 +1707,7 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 				Schema:                schema,
 				SchemaField:           "allOf",
 				Reason:                `doesn't match all schemas from "allOf"`,
-				Origin:                err,
+				Origin:                markSchemaErrorSchemaKeys(err, "allOf", strconv.Itoa(idx)),
 				customizeMessageError: settings.customizeMessageError,
 			}
 		}
@@ -1342,7 +1720,7 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 // https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#data-types
 // https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#schema-object
 func (schema *Schema) visitJSONNull(settings *schemaValidationSettings) (err error) {
-	if schema.Nullable {
+	if schema.Nullable || schema.Type.Includes(TypeNull) {
 		return
 	}
 	if settings.failfast {
@@ -1363,7 +1741,7 @@ func (schema *Schema) VisitJSONBoolean(value bool) error {
 }
 
 func (schema *Schema) visitJSONBoolean(settings *schemaValidationSettings, value bool) (err error) {
-	if schemaType := schema.Type; schemaType != "" && schemaType != TypeBoolean {
+	if !schema.Type.Permits(TypeBoolean) {
 		return schema.expectedType(settings, value)
 	}
 	return
@@ -1377,7 +1755,7 @@ func (schema *Schema) VisitJSONNumber(value float64) error {
 func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value float64) error {
 	var me MultiError
 	schemaType := schema.Type
-	if schemaType == TypeInteger {
+	if !schemaType.Permits(TypeNumber) && schemaType.Includes(TypeInteger) {
 		if bigFloat := big.NewFloat(value); !bigFloat.IsInt() {
 			if settings.failfast {
 				return errSchema
@@ -1394,12 +1772,12 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 			}
 			me = append(me, err)
 		}
-	} else if schemaType != "" && schemaType != TypeNumber {
+	} else if !schemaType.Permits(TypeNumber) {
 		return schema.expectedType(settings, value)
 	}
 
 	// formats
-	if schemaType == TypeInteger && schema.Format != "" {
+	if !schemaType.Permits(TypeNumber) && schema.Format != "" && !settings.formatAssertionsDisabled {
 		formatMin := float64(0)
 		formatMax := float64(0)
 		switch schema.Format {
@@ -1450,6 +1828,24 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 		me = append(me, err)
 	}
 
+	// "exclusiveMinimum" (OpenAPI 3.1)
+	if v := schema.ExclusiveMinValue; v != nil && !(*v < value) {
+		if settings.failfast {
+			return errSchema
+		}
+		err := &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           "exclusiveMinimum",
+			Reason:                fmt.Sprintf("number must be more than %g", *v),
+			customizeMessageError: settings.customizeMessageError,
+		}
+		if !settings.multiError {
+			return err
+		}
+		me = append(me, err)
+	}
+
 	// "exclusiveMaximum"
 	if v := schema.ExclusiveMax; v && !(*schema.Max > value) {
 		if settings.failfast {
@@ -1468,6 +1864,24 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 		me = append(me, err)
 	}
 
+	// "exclusiveMaximum" (OpenAPI 3.1)
+	if v := schema.ExclusiveMaxValue; v != nil && !(*v > value) {
+		if settings.failfast {
+			return errSchema
+		}
+		err := &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           "exclusiveMaximum",
+			Reason:                fmt.Sprintf("number must be less than %g", *v),
+			customizeMessageError: settings.customizeMessageError,
+		}
+		if !settings.multiError {
+			return err
+		}
+		me = append(me, err)
+	}
+
 	// "minimum"
 	if v := schema.Min; v != nil && !(*v <= value) {
 		if settings.failfast {
@@ -1539,7 +1953,7 @@ func (schema *Schema) VisitJSONString(value string) error {
 }
 
 func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value string) error {
-	if schemaType := schema.Type; schemaType != "" && schemaType != TypeString {
+	if !schema.Type.Permits(TypeString) {
 		return schema.expectedType(settings, value)
 	}
 
@@ -1549,15 +1963,8 @@ func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value
 	minLength := schema.MinLength
 	maxLength := schema.MaxLength
 	if minLength != 0 || maxLength != nil {
-		// JSON schema string lengths are UTF-16, not UTF-8!
-		length := int64(0)
-		for _, r := range value {
-			if utf16.IsSurrogate(r) {
-				length += 2
-			} else {
-				length++
-			}
-		}
+		// JSON schema string lengths are the number of Unicode code points, not bytes!
+		length := int64(utf8.RuneCountInString(value))
 		if minLength != 0 && length < int64(minLength) {
 			if settings.failfast {
 				return errSchema
@@ -1594,18 +2001,14 @@ func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value
 
 	// "pattern"
 	if !settings.patternValidationDisabled && schema.Pattern != "" {
-		cpiface, _ := compiledPatterns.Load(schema.Pattern)
-		cp, _ := cpiface.(*regexp.Regexp)
-		if cp == nil {
-			var err error
-			if cp, err = schema.compilePattern(); err != nil {
-				if !settings.multiError {
-					return err
-				}
-				me = append(me, err)
+		cp, err := schema.compilePattern()
+		if err != nil {
+			if !settings.multiError {
+				return err
 			}
+			me = append(me, err)
 		}
-		if !cp.MatchString(value) {
+		if cp != nil && !cp.MatchString(value) {
 			err := &SchemaError{
 				Value:                 value,
 				Schema:                schema,
@@ -1623,7 +2026,7 @@ func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value
 	// "format"
 	var formatStrErr string
 	var formatErr error
-	if format := schema.Format; format != "" {
+	if format := schema.Format; format != "" && !settings.formatAssertionsDisabled {
 		if f, ok := SchemaStringFormats[format]; ok {
 			switch {
 			case f.regexp != nil && f.callback == nil:
@@ -1674,7 +2077,7 @@ func (schema *Schema) VisitJSONArray(value []interface{}) error {
 }
 
 func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value []interface{}) error {
-	if schemaType := schema.Type; schemaType != "" && schemaType != TypeArray {
+	if !schema.Type.Permits(TypeArray) {
 		return schema.expectedType(settings, value)
 	}
 
@@ -1747,7 +2150,7 @@ func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value [
 		}
 		for i, item := range value {
 			if err := itemSchema.visitJSON(settings, item); err != nil {
-				err = markSchemaErrorIndex(err, i)
+				err = markSchemaErrorIndex(err, i, "items")
 				if !settings.multiError {
 					return err
 				}
@@ -1773,7 +2176,7 @@ func (schema *Schema) VisitJSONObject(value map[string]interface{}) error {
 }
 
 func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value map[string]interface{}) error {
-	if schemaType := schema.Type; schemaType != "" && schemaType != TypeObject {
+	if !schema.Type.Permits(TypeObject) {
 		return schema.expectedType(settings, value)
 	}
 
@@ -1870,7 +2273,7 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 					if settings.failfast {
 						return errSchema
 					}
-					err = markSchemaErrorKey(err, k)
+					err = markSchemaErrorKey(err, k, "properties", k)
 					if !settings.multiError {
 						return err
 					}
@@ -1889,7 +2292,7 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 					if settings.failfast {
 						return errSchema
 					}
-					err = markSchemaErrorKey(err, k)
+					err = markSchemaErrorKey(err, k, "additionalProperties")
 					if !settings.multiError {
 						return err
 					}
@@ -1956,16 +2359,20 @@ func (schema *Schema) expectedType(settings *schemaValidationSettings, value int
 		return errSchema
 	}
 
-	a := "a"
-	switch schema.Type {
-	case TypeArray, TypeObject, TypeInteger:
-		a = "an"
+	reason := fmt.Sprintf("value must be one of %s", schema.Type)
+	if len(schema.Type) == 1 {
+		a := "a"
+		switch schema.Type[0] {
+		case TypeArray, TypeObject, TypeInteger:
+			a = "an"
+		}
+		reason = fmt.Sprintf("value must be %s %s", a, schema.Type[0])
 	}
 	return &SchemaError{
 		Value:                 value,
 		Schema:                schema,
 		SchemaField:           "type",
-		Reason:                fmt.Sprintf("value must be %s %s", a, schema.Type),
+		Reason:                reason,
 		customizeMessageError: settings.customizeMessageError,
 	}
 }
@@ -1973,6 +2380,9 @@ func (schema *Schema) expectedType(settings *schemaValidationSettings, value int
 // NOTE: racey WRT [writes to schema.Pattern] vs [reads schema.Pattern then writes to compiledPatterns]
 func (schema *Schema) compilePattern() (cp *regexp.Regexp, err error) {
 	pattern := schema.Pattern
+	if cpiface, ok := compiledPatterns.Load(pattern); ok {
+		return cpiface.(*regexp.Regexp), nil
+	}
 	if cp, err = regexp.Compile(pattern); err != nil {
 		err = &SchemaError{
 			Schema:      schema,
@@ -1982,7 +2392,7 @@ func (schema *Schema) compilePattern() (cp *regexp.Regexp, err error) {
 		}
 		return
 	}
-	var _ bool = compiledPatterns.CompareAndSwap(pattern, nil, cp)
+	compiledPatterns.Store(pattern, cp)
 	return
 }
 
@@ -1992,6 +2402,8 @@ type SchemaError struct {
 	Value interface{}
 	// reversePath is the path to the value that failed validation.
 	reversePath []string
+	// reverseSchemaPath is the path to Schema from the schema the validation started at.
+	reverseSchemaPath []string
 	// Schema is the schema that failed validation.
 	Schema *Schema
 	// SchemaField is the field of the schema that failed validation.
@@ -2007,7 +2419,19 @@ type SchemaError struct {
 
 var _ interface{ Unwrap() error } = SchemaError{}
 
-func markSchemaErrorKey(err error, key string) error {
+// markSchemaErrorKey locates err at key of the value and, when given,
+// at the schemaKeys of the schema.
+func markSchemaErrorKey(err error, key string, schemaKeys ...string) error {
+	return markSchemaError(err, []string{key}, schemaKeys)
+}
+
+// markSchemaErrorSchemaKeys locates err at the schemaKeys of the schema,
+// for errors of subschemas validating the same value (e.g. "allOf", "0").
+func markSchemaErrorSchemaKeys(err error, schemaKeys ...string) error {
+	return markSchemaError(err, nil, schemaKeys)
+}
+
+func markSchemaError(err error, keys, schemaKeys []string) error {
 	var me multiErrorForOneOf
 
 	if errors.As(err, &me) {
@@ -2015,20 +2439,33 @@ func markSchemaErrorKey(err error, key string) error {
 	}
 
 	if v, ok := err.(*SchemaError); ok {
-		v.reversePath = append(v.reversePath, key)
+		v.reversePath = append(v.reversePath, keys...)
+		for i := len(schemaKeys) - 1; i >= 0; i-- {
+			v.reverseSchemaPath = append(v.reverseSchemaPath, schemaKeys[i])
+		}
+		// Errors of nested values (e.g. from "allOf") are located from the same root
+		for origin := v.Origin; origin != nil; origin = errors.Unwrap(origin) {
+			switch origin.(type) {
+			case *SchemaError, MultiError:
+				_ = markSchemaError(origin, keys, schemaKeys)
+			default:
+				continue
+			}
+			break
+		}
 		return v
 	}
 	if v, ok := err.(MultiError); ok {
 		for _, e := range v {
-			_ = markSchemaErrorKey(e, key)
+			_ = markSchemaError(e, keys, schemaKeys)
 		}
 		return v
 	}
 	return err
 }
 
-func markSchemaErrorIndex(err error, index int) error {
-	return markSchemaErrorKey(err, strconv.FormatInt(int64(index), 10))
+func markSchemaErrorIndex(err error, index int, schemaKeys ...string) error {
+	return markSchemaErrorKey(err, strconv.FormatInt(int64(index), 10), schemaKeys...)
 }
 
 func (err *SchemaError) JSONPointer() []string {
@@ -2047,6 +2484,11 @@ func (err *SchemaError) Error() string {
 		}
 	}
 
+	if origin, ok := err.Origin.(*SchemaError); ok && len(origin.reversePath) > 0 {
+		// The origin is located from the same root, mentioning both would repeat the path
+		return origin.Error()
+	}
+
 	buf := bytes.NewBuffer(make([]byte, 0, 256))
 
 	if len(err.reversePath) > 0 {
@@ -2095,15 +2537,18 @@ func (err SchemaError) Unwrap() error {
 }
 
 func isSliceOfUniqueItems(xs []interface{}) bool {
-	s := len(xs)
-	m := make(map[string]struct{}, s)
+	m := make(map[string]struct{}, len(xs))
 	for _, x := range xs {
 		// The input slice is converted from a JSON string, there shall
 		// have no error when convert it back.
+		// Object keys are sorted by json.Marshal so the encoding is canonical.
 		key, _ := json.Marshal(&x)
+		if _, ok := m[string(key)]; ok {
+			return false
+		}
 		m[string(key)] = struct{}{}
 	}
-	return s == len(m)
+	return true
 }
 
 // SliceUniqueItemsChecker is an function used to check if an given slice
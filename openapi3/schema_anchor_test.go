@@ -0,0 +1,104 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaAnchorsMarshaling(t *testing.T) {
+	data := []byte(`{"$anchor":"node","$dynamicAnchor":"item","$dynamicRef":"#item","type":"object"}`)
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.Equal(t, "node", schema.Anchor)
+	require.Equal(t, "item", schema.DynamicAnchor)
+	require.Equal(t, "#item", schema.DynamicRef)
+	require.Empty(t, schema.Extensions)
+
+	got, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(got))
+}
+
+func TestLoaderResolvesSchemaAnchor(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Anchors
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          $anchor: name
+          type: string
+    Owner:
+      type: object
+      properties:
+        petName:
+          $ref: '#name'
+    Missing:
+      $ref: '#unknown'
+`)
+
+	loader := NewLoader()
+	_, err := loader.LoadFromData(spec)
+	require.EqualError(t, err, `cannot find $anchor "unknown" of reference "#unknown"`)
+
+	spec = spec[:len(spec)-len("    Missing:\n      $ref: '#unknown'\n")]
+	loader = NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	petName := doc.Components.Schemas["Owner"].Value.Properties["petName"]
+	require.Equal(t, "#name", petName.Ref)
+	require.Same(t, doc.Components.Schemas["Pet"].Value.Properties["name"].Value, petName.Value)
+	require.NoError(t, doc.Validate(context.Background()))
+}
+
+func TestSchemaVisitJSONDynamicRef(t *testing.T) {
+	// A generic tree whose nodes are extended by the strict tree referring to it.
+	tree := &Schema{
+		DynamicAnchor: "node",
+		Type:          SchemaTypes{TypeObject},
+		Properties: Schemas{
+			"children": NewArraySchema().WithItems(&Schema{DynamicRef: "#node"}).NewRef(),
+		},
+	}
+	strictTree := &Schema{
+		DynamicAnchor: "node",
+		AllOf:         SchemaRefs{{Ref: "#/components/schemas/Tree", Value: tree}},
+		Properties: Schemas{
+			"children": NewArraySchema().NewRef(),
+			"data":     NewStringSchema().NewRef(),
+		},
+		AdditionalProperties: AdditionalProperties{Has: BoolPtr(false)},
+	}
+
+	value := map[string]interface{}{
+		"children": []interface{}{
+			map[string]interface{}{"data": "leaf"},
+		},
+	}
+	require.NoError(t, tree.VisitJSON(value))
+	require.NoError(t, strictTree.VisitJSON(value))
+
+	value = map[string]interface{}{
+		"children": []interface{}{
+			map[string]interface{}{"daat": "leaf"},
+		},
+	}
+	require.NoError(t, tree.VisitJSON(value))
+	err := strictTree.VisitJSON(value)
+	require.ErrorContains(t, err, `property "daat" is unsupported`)
+
+	unresolved := &Schema{DynamicRef: "#unknown"}
+	err = unresolved.VisitJSON("x")
+	require.ErrorContains(t, err, `unresolved $dynamicRef "#unknown"`)
+}
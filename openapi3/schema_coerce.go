@@ -0,0 +1,56 @@
+package openapi3
+
+import "strconv"
+
+// coerce returns a copy of value where the strings are converted to the type
+// declared by their schema, when it is unambiguous and the conversion succeeds.
+func (schema *Schema) coerce(value interface{}) interface{} {
+	if schema == nil || len(schema.OneOf) != 0 || len(schema.AnyOf) != 0 || len(schema.AllOf) != 0 {
+		return value
+	}
+
+	switch value := value.(type) {
+	case string:
+		if value == "null" && schema.Nullable && !schema.Type.Includes(TypeString) {
+			return nil
+		}
+		if len(schema.Type) != 1 {
+			return value
+		}
+		switch schema.Type[0] {
+		case TypeInteger:
+			if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return float64(i)
+			}
+		case TypeNumber:
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				return f
+			}
+		case TypeBoolean:
+			if b, err := strconv.ParseBool(value); err == nil {
+				return b
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil {
+			return value
+		}
+		coerced := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			coerced = append(coerced, schema.Items.Value.coerce(item))
+		}
+		return coerced
+	case map[string]interface{}:
+		coerced := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			if property := schema.Properties[k]; property != nil {
+				v = property.Value.coerce(v)
+			} else if additional := schema.AdditionalProperties.Schema; additional != nil {
+				v = additional.Value.coerce(v)
+			}
+			coerced[k] = v
+		}
+		return coerced
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVisitJSONCoerce(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  *Schema
+		value   interface{}
+		coerced interface{}
+		wantErr string
+	}{
+		{
+			name:    "integer",
+			schema:  NewIntegerSchema(),
+			value:   "42",
+			coerced: float64(42),
+		},
+		{
+			name:    "number",
+			schema:  NewFloat64Schema(),
+			value:   "3.14",
+			coerced: 3.14,
+		},
+		{
+			name:    "boolean",
+			schema:  NewBoolSchema(),
+			value:   "true",
+			coerced: true,
+		},
+		{
+			name:    "null",
+			schema:  NewIntegerSchema().WithNullable(true),
+			value:   "null",
+			coerced: nil,
+		},
+		{
+			name:    "string",
+			schema:  NewStringSchema().WithNullable(true),
+			value:   "null",
+			coerced: "null",
+		},
+		{
+			name: "object",
+			schema: NewObjectSchema().
+				WithProperty("id", NewIntegerSchema()).
+				WithProperty("tags", NewArraySchema().WithItems(NewBoolSchema())).
+				WithAdditionalProperties(NewFloat64Schema()),
+			value:   map[string]interface{}{"id": "1", "tags": []interface{}{"true", "false"}, "ratio": "0.5"},
+			coerced: map[string]interface{}{"id": float64(1), "tags": []interface{}{true, false}, "ratio": 0.5},
+		},
+		{
+			name:    "invalid",
+			schema:  NewIntegerSchema(),
+			value:   "4.2",
+			coerced: "4.2",
+			wantErr: "value must be an integer",
+		},
+		{
+			name:    "ambiguous",
+			schema:  NewAnyOfSchema(NewIntegerSchema(), NewBoolSchema()),
+			value:   "1",
+			coerced: "1",
+			wantErr: `doesn't match any schema from "anyOf"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var coerced interface{}
+			err := tt.schema.VisitJSON(tt.value, WithCoerce(true), CoercedValue(&coerced))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.coerced, coerced)
+		})
+	}
+
+	var value interface{}
+	err := NewIntegerSchema().VisitJSON("42", CoercedValue(&value))
+	require.ErrorContains(t, err, "value must be an integer")
+	require.Equal(t, "42", value)
+}
@@ -0,0 +1,159 @@
+package openapi3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaExpandOption allows the modification of how a schema is expanded.
+type SchemaExpandOption func(*schemaExpandSettings)
+
+type schemaExpandSettings struct {
+	maxDepth int
+}
+
+// ExpandMaxDepth makes Expand fail on schemas nested deeper than depth.
+// A depth of 0 (the default) does not limit the expansion.
+func ExpandMaxDepth(depth int) SchemaExpandOption {
+	return func(settings *schemaExpandSettings) { settings.maxDepth = depth }
+}
+
+// CircularSchemaDescription is the description of the schema that replaces
+// circular references in expanded schemas.
+const CircularSchemaDescription = "[circular]"
+
+func newCircularSchema() *Schema {
+	return &Schema{Type: SchemaTypes{TypeObject}, Description: CircularSchemaDescription}
+}
+
+type schemaExpander struct {
+	components *Components
+	settings   schemaExpandSettings
+	stack      map[*Schema]struct{}
+}
+
+// Expand returns a copy of the schema where all references are replaced by their targets,
+// recursively. Local references ("#/components/schemas/...") are resolved in components,
+// other references use their loaded value.
+// A reference to a schema being expanded, or to itself through other references
+// (e.g. A to B to A), is replaced with an object schema described as CircularSchemaDescription.
+func (schema *Schema) Expand(components *Components, opts ...SchemaExpandOption) (*Schema, error) {
+	e := &schemaExpander{
+		components: components,
+		stack:      make(map[*Schema]struct{}),
+	}
+	for _, opt := range opts {
+		opt(&e.settings)
+	}
+	return e.expand(schema, 0)
+}
+
+func (e *schemaExpander) expand(schema *Schema, depth int) (*Schema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	if _, ok := e.stack[schema]; ok {
+		return newCircularSchema(), nil
+	}
+	if max := e.settings.maxDepth; max > 0 && depth > max {
+		return nil, fmt.Errorf("schema is nested deeper than %d", max)
+	}
+	e.stack[schema] = struct{}{}
+	defer delete(e.stack, schema)
+
+	expanded := *schema
+	var err error
+	if expanded.OneOf, err = e.expandRefs(schema.OneOf, depth); err != nil {
+		return nil, err
+	}
+	if expanded.AnyOf, err = e.expandRefs(schema.AnyOf, depth); err != nil {
+		return nil, err
+	}
+	if expanded.AllOf, err = e.expandRefs(schema.AllOf, depth); err != nil {
+		return nil, err
+	}
+	if expanded.Not, err = e.expandRef(schema.Not, depth); err != nil {
+		return nil, err
+	}
+	if expanded.Items, err = e.expandRef(schema.Items, depth); err != nil {
+		return nil, err
+	}
+	if expanded.AdditionalProperties.Schema, err = e.expandRef(schema.AdditionalProperties.Schema, depth); err != nil {
+		return nil, err
+	}
+	if schema.Properties != nil {
+		expanded.Properties = make(Schemas, len(schema.Properties))
+		for name, property := range schema.Properties {
+			if expanded.Properties[name], err = e.expandRef(property, depth); err != nil {
+				return nil, fmt.Errorf("property %q: %w", name, err)
+			}
+		}
+	}
+	return &expanded, nil
+}
+
+func (e *schemaExpander) expandRefs(refs SchemaRefs, depth int) (SchemaRefs, error) {
+	if refs == nil {
+		return nil, nil
+	}
+	expanded := make(SchemaRefs, 0, len(refs))
+	for _, ref := range refs {
+		v, err := e.expandRef(ref, depth)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, v)
+	}
+	return expanded, nil
+}
+
+func (e *schemaExpander) expandRef(ref *SchemaRef, depth int) (*SchemaRef, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	value, err := e.resolve(ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return &SchemaRef{Value: newCircularSchema()}, nil
+	}
+	expanded, err := e.expand(value, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaRef{Value: expanded}, nil
+}
+
+// resolve returns the schema ref points to, following the references in seen,
+// or nil when these references form a cycle.
+func (e *schemaExpander) resolve(ref *SchemaRef, seen map[string]struct{}) (*Schema, error) {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref.Ref, prefix) {
+		if ref.Value == nil {
+			return nil, fmt.Errorf("unresolved reference %q", ref.Ref)
+		}
+		return ref.Value, nil
+	}
+	if _, ok := seen[ref.Ref]; ok {
+		return nil, nil
+	}
+	if e.components != nil {
+		if target := e.components.Schemas[strings.TrimPrefix(ref.Ref, prefix)]; target != nil {
+			if target.Ref != "" {
+				if seen == nil {
+					seen = make(map[string]struct{})
+				}
+				seen[ref.Ref] = struct{}{}
+				return e.resolve(target, seen)
+			}
+			if target.Value != nil {
+				return target.Value, nil
+			}
+		}
+	}
+	if ref.Value != nil {
+		return ref.Value, nil
+	}
+	return nil, fmt.Errorf("unresolved reference %q", ref.Ref)
+}
@@ -0,0 +1,82 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaExpand(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: MyAPI, version: "0.1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tag: {$ref: "#/components/schemas/Tag"}
+        parent: {$ref: "#/components/schemas/Pet"}
+    Tag:
+      type: string
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	pet := doc.Components.Schemas["Pet"].Value
+	expanded, err := pet.Expand(doc.Components)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(expanded)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "tag": {"type": "string"},
+    "parent": {"type": "object", "description": "[circular]"}
+  }
+}`, string(data))
+
+	// The original schema is left untouched
+	require.Equal(t, "#/components/schemas/Tag", pet.Properties["tag"].Ref)
+
+	_, err = pet.Expand(doc.Components, ExpandMaxDepth(0))
+	require.NoError(t, err)
+	_, err = NewObjectSchema().WithProperty("pet", pet).Expand(doc.Components, ExpandMaxDepth(1))
+	require.EqualError(t, err, `property "pet": property "tag": schema is nested deeper than 1`)
+}
+
+func TestSchemaExpandUnresolved(t *testing.T) {
+	schema := NewArraySchema()
+	schema.Items = &SchemaRef{Ref: "#/components/schemas/Missing"}
+	_, err := schema.Expand(&Components{})
+	require.EqualError(t, err, `unresolved reference "#/components/schemas/Missing"`)
+}
+
+func TestSchemaExpandCircularRefs(t *testing.T) {
+	components := &Components{
+		Schemas: Schemas{
+			"Self": &SchemaRef{Ref: "#/components/schemas/Self"},
+			"A":    &SchemaRef{Ref: "#/components/schemas/B"},
+			"B":    &SchemaRef{Ref: "#/components/schemas/A"},
+		},
+	}
+	schema := NewObjectSchema().
+		WithPropertyRef("self", &SchemaRef{Ref: "#/components/schemas/Self"}).
+		WithPropertyRef("a", &SchemaRef{Ref: "#/components/schemas/A"})
+
+	expanded, err := schema.Expand(components)
+	require.NoError(t, err)
+	data, err := json.Marshal(expanded)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "self": {"type": "object", "description": "[circular]"},
+    "a": {"type": "object", "description": "[circular]"}
+  }
+}`, string(data))
+}
@@ -0,0 +1,38 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// WriteToFile writes the JSON encoding of the schema to the file at location.
+func (schema *Schema) WriteToFile(location string) error {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(location, data, 0o644)
+}
+
+// ReadSchemaFromFile loads a standalone JSON or YAML schema from the file at location.
+// References to other files are resolved relative to location.
+func ReadSchemaFromFile(location string) (*Schema, error) {
+	loader := NewLoader(WithExternalRefs(true))
+	loader.rootDir = path.Dir(location)
+	uri := &url.URL{Path: filepath.ToSlash(location)}
+	data, err := loader.readURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{}
+	if err := unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+	if err := loader.resolveSchemaRef(&T{}, schema.NewRef(), uri, []string{}); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
@@ -0,0 +1,30 @@
+package openapi3
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	tag := NewStringSchema().WithMaxLength(10)
+	err := tag.WriteToFile(filepath.Join(dir, "tag.json"))
+	require.NoError(t, err)
+
+	pet := NewObjectSchema().WithPropertyRef("tag", &SchemaRef{Ref: "tag.json"})
+	err = pet.WriteToFile(filepath.Join(dir, "pet.json"))
+	require.NoError(t, err)
+
+	loaded, err := ReadSchemaFromFile(filepath.Join(dir, "pet.json"))
+	require.NoError(t, err)
+	require.Equal(t, SchemaTypes{"object"}, loaded.Type)
+	require.Equal(t, "tag.json", loaded.Properties["tag"].Ref)
+	require.Equal(t, SchemaTypes{"string"}, loaded.Properties["tag"].Value.Type)
+	require.Equal(t, tag.MaxLength, loaded.Properties["tag"].Value.MaxLength)
+
+	_, err = ReadSchemaFromFile(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
@@ -1,16 +1,21 @@
 package openapi3
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"regexp"
 	"strings"
+	"time"
 )
 
 const (
 	// FormatOfStringForUUIDOfRFC4122 is an optional predefined format for UUID v1-v5 as specified by RFC4122
 	FormatOfStringForUUIDOfRFC4122 = `^(?:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[1-5][0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}|00000000-0000-0000-0000-000000000000)$`
 
+	// FormatOfStringForUUID is the default format for UUIDs: 32 hexadecimal digits grouped as 8-4-4-4-12
+	FormatOfStringForUUID = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
 	// FormatOfStringForEmail pattern catches only some suspiciously wrong-looking email addresses.
 	// Use DefineStringFormat(...) if you need something stricter.
 	FormatOfStringForEmail = `^[^@]+@[^@<>",\s]+$`
@@ -82,16 +87,33 @@ func validateIPv6(ip string) error {
 	return nil
 }
 
+func validateDate(value string) error {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return errors.New("not a valid date")
+	}
+	return nil
+}
+
+func validateDateTime(value string) error {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return errors.New("not a valid RFC 3339 date-time")
+	}
+	return nil
+}
+
 func init() {
 	// Base64
 	// The pattern supports base64 and b./ase64url. Padding ('=') is supported.
 	DefineStringFormat("byte", `(^$|^[a-zA-Z0-9+/\-_]*=*$)`)
 
+	// uuid
+	DefineStringFormat("uuid", FormatOfStringForUUID)
+
 	// date
-	DefineStringFormat("date", `^[0-9]{4}-(0[0-9]|10|11|12)-([0-2][0-9]|30|31)$`)
+	DefineStringFormatCallback("date", validateDate)
 
 	// date-time
-	DefineStringFormat("date-time", `^[0-9]{4}-(0[0-9]|10|11|12)-([0-2][0-9]|30|31)T[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?(Z|(\+|-)[0-9]{2}:[0-9]{2})?$`)
+	DefineStringFormatCallback("date-time", validateDateTime)
 
 }
 
@@ -104,3 +126,8 @@ func DefineIPv4Format() {
 func DefineIPv6Format() {
 	DefineStringFormatCallback("ipv6", validateIPv6)
 }
+
+// DefineStrictUUIDFormat opts in checking the version and variant of UUIDs as per RFC 4122
+func DefineStrictUUIDFormat() {
+	DefineStringFormat("uuid", FormatOfStringForUUIDOfRFC4122)
+}
@@ -115,7 +115,8 @@ func TestUuidFormat(t *testing.T) {
 		wantErr bool
 	}
 
-	DefineStringFormat("uuid", FormatOfStringForUUIDOfRFC4122)
+	DefineStrictUUIDFormat()
+	defer DefineStringFormat("uuid", FormatOfStringForUUID)
 	testCases := []testCase{
 		{
 			name:    "invalid",
@@ -154,3 +155,87 @@ func TestUuidFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultUUIDFormat(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"77e66540-ca29-11ed-afa1-0242ac120002":          true,
+		"00000000-0000-0000-0000-000000000000":          true,
+		"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF":          true,
+		"00f4d301-b9f4-9366-1907-2b5a03430aa1":          true, // neither RFC 4122 version nor variant
+		"{00000000-0000-0000-0000-000000000000}":        false,
+		"00000000000000000000000000000000":              false,
+		"00000000-0000-0000-0000-00000000000":           false,
+		"00000000-0000-0000-0000-0000000000000":         false,
+		"0000000-00000-0000-0000-000000000000":          false,
+		"g0000000-0000-0000-0000-000000000000":          false,
+		"urn:uuid:00000000-0000-0000-0000-000000000000": false,
+	} {
+		err := NewUUIDSchema().VisitJSON(value)
+		if valid {
+			require.NoError(t, err, value)
+		} else {
+			require.Error(t, err, value)
+		}
+	}
+
+	DefineStrictUUIDFormat()
+	defer DefineStringFormat("uuid", FormatOfStringForUUID)
+	require.Error(t, NewUUIDSchema().VisitJSON("00f4d301-b9f4-9366-1907-2b5a03430aa1"))
+}
+
+func TestDisabledSchemaFormatAssertions(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Events
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Event:
+      type: object
+      properties:
+        day:
+          type: string
+          format: date
+        count:
+          type: integer
+          format: int32
+          default: 4294967296
+        code:
+          type: string
+          format: legacy-code
+          maxLength: 3
+      example:
+        day: 2023-02-30
+        code: abc
+`[1:]
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(loader.Context)
+	require.ErrorContains(t, err, "number must be an int32")
+
+	err = doc.Validate(loader.Context, EnableSchemaFormatValidation())
+	require.ErrorContains(t, err, `unsupported 'format' value "legacy-code"`)
+
+	for _, opts := range [][]ValidationOption{
+		{WithDisabledSchemaFormatAssertions()},
+		{WithDisabledSchemaFormatAssertions(), EnableSchemaFormatValidation()},
+		{EnableSchemaFormatValidation(), WithDisabledSchemaFormatAssertions()},
+	} {
+		require.NoError(t, doc.Validate(loader.Context, opts...))
+	}
+
+	// Other keywords are still validated
+	doc.Components.Schemas["Event"].Value.Example = map[string]interface{}{"code": "abcd"}
+	err = doc.Validate(loader.Context, WithDisabledSchemaFormatAssertions())
+	require.ErrorContains(t, err, "maximum string length is 3")
+
+	schema := NewStringSchema().WithFormat("date")
+	require.Error(t, schema.VisitJSON("2023-02-30"))
+	require.NoError(t, schema.VisitJSON("2023-02-30", DisableFormatAssertions()))
+	require.NoError(t, NewStringSchema().WithFormat("legacy-code").VisitJSON("x", EnableFormatValidation(), DisableFormatAssertions()))
+}
@@ -0,0 +1,114 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// encodeNullableSchemas rewrites the nullable schemas of data, the JSON encoding of doc,
+// in the form of the OpenAPI version of doc: a "null" type from 3.1 on
+// and `nullable: true` before.
+func (doc *T) encodeNullableSchemas(data []byte) ([]byte, error) {
+	typeNull := openAPIMinorVersion(doc.OpenAPI) >= 1
+	var pointers []string
+	doc.walkSchemas(&schemaWalker{
+		visit: func(pointer string, schema *Schema) {
+			if typeNull && schema.Nullable || !typeNull && schema.Type.Includes(TypeNull) {
+				pointers = append(pointers, pointer)
+			}
+		},
+	})
+	if len(pointers) == 0 {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var encoded interface{}
+	if err := decoder.Decode(&encoded); err != nil {
+		return nil, err
+	}
+	for _, pointer := range pointers {
+		m, ok := jsonPointerObject(encoded, pointer)
+		if !ok || m["$ref"] != nil {
+			// Schemas reached through references are encoded where they are defined
+			continue
+		}
+		if typeNull {
+			encodeNullAsType(m)
+		} else {
+			encodeNullAsNullable(m)
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// encodeNullAsType replaces `nullable: true` with a "null" type in the encoded schema m.
+// Schemas without type already allow null values.
+func encodeNullAsType(m map[string]interface{}) {
+	delete(m, "nullable")
+	switch types := m["type"].(type) {
+	case string:
+		if types != TypeNull {
+			m["type"] = []interface{}{types, TypeNull}
+		}
+	case []interface{}:
+		for _, t := range types {
+			if t == TypeNull {
+				return
+			}
+		}
+		m["type"] = append(types, TypeNull)
+	}
+}
+
+// encodeNullAsNullable replaces the "null" type of the encoded schema m with `nullable: true`.
+func encodeNullAsNullable(m map[string]interface{}) {
+	var types []interface{}
+	switch t := m["type"].(type) {
+	case string:
+		if t != TypeNull {
+			types = append(types, t)
+		}
+	case []interface{}:
+		for _, t := range t {
+			if t != TypeNull {
+				types = append(types, t)
+			}
+		}
+	}
+	switch len(types) {
+	case 0:
+		delete(m, "type")
+	case 1:
+		m["type"] = types[0]
+	default:
+		m["type"] = types
+	}
+	m["nullable"] = true
+}
+
+// jsonPointerObject returns the object of the decoded JSON value at pointer.
+func jsonPointerObject(value interface{}, pointer string) (map[string]interface{}, bool) {
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = jsonpointer.Unescape(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	m, ok := value.(map[string]interface{})
+	return m, ok
+}
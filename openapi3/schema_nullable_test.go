@@ -0,0 +1,71 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalNullableSchemas(t *testing.T) {
+	newDoc := func(version string) *T {
+		pet := NewObjectSchema().
+			WithProperty("name", NewStringSchema().WithNullable(true)).
+			WithProperty("tags", NewArraySchema().WithItems(NewStringSchema()).WithNullable(true)).
+			WithProperty("age", &Schema{Type: SchemaTypes{TypeInteger, TypeNull}}).
+			WithProperty("any", NewSchema().WithNullable(true)).
+			WithPropertyRef("owner", &SchemaRef{Ref: "#/components/schemas/Owner", Value: NewStringSchema().WithNullable(true)})
+		return &T{
+			OpenAPI: version,
+			Info:    &Info{Title: "MyAPI", Version: "0.1"},
+			Paths:   Paths{},
+			Components: &Components{
+				Schemas: Schemas{
+					"Pet":   pet.NewRef(),
+					"Owner": NewStringSchema().WithNullable(true).NewRef(),
+				},
+			},
+		}
+	}
+
+	for version, expected := range map[string]string{
+		"3.0.3": `{
+  "Owner": {"type": "string", "nullable": true},
+  "Pet": {
+    "type": "object",
+    "properties": {
+      "name": {"type": "string", "nullable": true},
+      "tags": {"type": "array", "items": {"type": "string"}, "nullable": true},
+      "age": {"type": "integer", "nullable": true},
+      "any": {"nullable": true},
+      "owner": {"$ref": "#/components/schemas/Owner"}
+    }
+  }
+}`,
+		"3.1.0": `{
+  "Owner": {"type": ["string", "null"]},
+  "Pet": {
+    "type": "object",
+    "properties": {
+      "name": {"type": ["string", "null"]},
+      "tags": {"type": ["array", "null"], "items": {"type": "string"}},
+      "age": {"type": ["integer", "null"]},
+      "any": {},
+      "owner": {"$ref": "#/components/schemas/Owner"}
+    }
+  }
+}`,
+	} {
+		data, err := json.Marshal(newDoc(version))
+		require.NoError(t, err, version)
+		var encoded struct {
+			Components struct {
+				Schemas json.RawMessage `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(data, &encoded), version)
+		require.JSONEq(t, expected, string(encoded.Components.Schemas), version)
+	}
+
+	require.False(t, NewStringSchema().WithNullable(true).WithNullable(false).Nullable)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"math"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -23,6 +24,7 @@ type schemaExample struct {
 
 func TestSchemas(t *testing.T) {
 	DefineStringFormat("uuid", FormatOfStringForUUIDOfRFC4122)
+	defer DefineStringFormat("uuid", FormatOfStringForUUID)
 	for _, example := range schemaExamples {
 		t.Run(example.Title, testSchema(t, example))
 	}
@@ -111,7 +113,7 @@ var schemaExamples = []schemaExample{
 
 	{
 		Title:  "JUST NULLABLE",
-		Schema: NewSchema().WithNullable(),
+		Schema: NewSchema().WithNullable(true),
 		Serialization: map[string]interface{}{
 			// This OA3 schema is exactly both this draft-04 schema: {} and:
 			// {anyOf: [type:string, type:number, type:integer, type:boolean
@@ -133,7 +135,7 @@ var schemaExamples = []schemaExample{
 
 	{
 		Title:  "NULLABLE BOOLEAN",
-		Schema: NewBoolSchema().WithNullable(),
+		Schema: NewBoolSchema().WithNullable(true),
 		Serialization: map[string]interface{}{
 			"nullable": true,
 			"type":     "boolean",
@@ -153,12 +155,41 @@ var schemaExamples = []schemaExample{
 		},
 	},
 
+	{
+		Title: "NULLABLE CONSTRAINED STRING",
+		Schema: &Schema{
+			Type:      SchemaTypes{TypeString},
+			Nullable:  true,
+			MinLength: 2,
+			Pattern:   "^a",
+			Enum:      []interface{}{"ab", "ac"},
+		},
+		Serialization: map[string]interface{}{
+			"nullable":  true,
+			"type":      "string",
+			"minLength": 2,
+			"pattern":   "^a",
+			"enum":      []interface{}{"ab", "ac"},
+		},
+		AllValid: []interface{}{
+			nil,
+			"ab",
+			"ac",
+		},
+		AllInvalid: []interface{}{
+			"",
+			"a",
+			"ba",
+			"abc",
+		},
+	},
+
 	{
 		Title: "NULLABLE ANYOF",
 		Schema: NewAnyOfSchema(
 			NewIntegerSchema(),
 			NewFloat64Schema(),
-		).WithNullable(),
+		).WithNullable(true),
 		Serialization: map[string]interface{}{
 			"nullable": true,
 			"anyOf": []interface{}{
@@ -304,10 +335,9 @@ var schemaExamples = []schemaExample{
 	},
 	{
 		Title: "STRING",
-		Schema: NewStringSchema().
+		Schema: mustWithPattern(NewStringSchema().
 			WithMinLength(2).
-			WithMaxLength(3).
-			WithPattern("^[abc]+$"),
+			WithMaxLength(3), "^[abc]+$"),
 		Serialization: map[string]interface{}{
 			"type":      "string",
 			"minLength": 2,
@@ -330,6 +360,30 @@ var schemaExamples = []schemaExample{
 			map[string]interface{}{},
 		},
 	},
+	{
+		Title: "STRING: length counts code points",
+		Schema: NewStringSchema().
+			WithMinLength(4).
+			WithMaxLength(4),
+		Serialization: map[string]interface{}{
+			"type":      "string",
+			"minLength": 4,
+			"maxLength": 4,
+		},
+		AllValid: []interface{}{
+			"cafe",
+			"café", // 5 bytes
+			"日本語!", // 10 bytes
+			"👍👍👍👍", // 16 bytes, 8 UTF-16 code units
+		},
+		AllInvalid: []interface{}{
+			"caf",
+			"cafés",
+			"日本語",
+			"👍👍👍",
+			"👍👍👍👍👍",
+		},
+	},
 
 	{
 		Title:  "STRING: optional format 'uuid'",
@@ -373,20 +427,60 @@ var schemaExamples = []schemaExample{
 			"format": "date-time",
 		},
 		AllValid: []interface{}{
-			"2017-12-31T11:59:59",
 			"2017-12-31T11:59:59Z",
 			"2017-12-31T11:59:59-11:30",
 			"2017-12-31T11:59:59+11:30",
 			"2017-12-31T11:59:59.999+11:30",
 			"2017-12-31T11:59:59.999Z",
+			"2020-02-29T00:00:00Z",
+			"2017-12-31T23:59:59+14:00",
+			"2017-01-01T00:00:00-12:00",
 		},
 		AllInvalid: []interface{}{
 			nil,
 			3.14,
 			"2017-12-31",
+			"2017-12-31T11:59:59",
 			"2017-12-31T11:59:59\n",
 			"2017-12-31T11:59:59.+11:30",
 			"2017-12-31T11:59:59.Z",
+			"2017-12-31 11:59:59Z",
+			"2017-02-29T00:00:00Z",
+			"2017-04-31T00:00:00Z",
+			"2017-12-31T24:00:00Z",
+			"2017-12-31T11:60:00Z",
+			"2017-12-31T11:59:59+25:00",
+			"2017-12-31T11:59:59+0100",
+		},
+	},
+
+	{
+		Title:  "STRING: format 'date'",
+		Schema: NewDateTimeSchema().WithFormat("date"),
+		Serialization: map[string]interface{}{
+			"type":   "string",
+			"format": "date",
+		},
+		AllValid: []interface{}{
+			"2017-12-31",
+			"2017-01-01",
+			"2020-02-29",
+			"2000-02-29",
+			"2017-04-30",
+		},
+		AllInvalid: []interface{}{
+			nil,
+			3.14,
+			"2017-12-31T11:59:59Z",
+			"2017-1-31",
+			"2017-02-29",
+			"1900-02-29",
+			"2023-02-30",
+			"2017-04-31",
+			"2017-13-01",
+			"2017-00-10",
+			"2017-12-00",
+			"2017-12-32",
 		},
 	},
 
@@ -425,7 +519,7 @@ var schemaExamples = []schemaExample{
 	{
 		Title: "ARRAY",
 		Schema: &Schema{
-			Type:        "array",
+			Type:        SchemaTypes{"array"},
 			MinItems:    2,
 			MaxItems:    Uint64Ptr(3),
 			UniqueItems: true,
@@ -465,10 +559,10 @@ var schemaExamples = []schemaExample{
 	{
 		Title: "ARRAY : items format 'object'",
 		Schema: &Schema{
-			Type:        "array",
+			Type:        SchemaTypes{"array"},
 			UniqueItems: true,
 			Items: (&Schema{
-				Type: "object",
+				Type: SchemaTypes{"object"},
 				Properties: Schemas{
 					"key1": NewFloat64Schema().NewRef(),
 				},
@@ -522,13 +616,13 @@ var schemaExamples = []schemaExample{
 	{
 		Title: "ARRAY : items format 'object' and object with a property of array type ",
 		Schema: &Schema{
-			Type:        "array",
+			Type:        SchemaTypes{"array"},
 			UniqueItems: true,
 			Items: (&Schema{
-				Type: "object",
+				Type: SchemaTypes{"object"},
 				Properties: Schemas{
 					"key1": (&Schema{
-						Type:        "array",
+						Type:        SchemaTypes{"array"},
 						UniqueItems: true,
 						Items:       NewFloat64Schema().NewRef(),
 					}).NewRef(),
@@ -608,10 +702,10 @@ var schemaExamples = []schemaExample{
 	{
 		Title: "ARRAY : items format 'array'",
 		Schema: &Schema{
-			Type:        "array",
+			Type:        SchemaTypes{"array"},
 			UniqueItems: true,
 			Items: (&Schema{
-				Type:        "array",
+				Type:        SchemaTypes{"array"},
 				UniqueItems: true,
 				Items:       NewFloat64Schema().NewRef(),
 			}).NewRef(),
@@ -652,13 +746,13 @@ var schemaExamples = []schemaExample{
 	{
 		Title: "ARRAY : items format 'array' and array with object type items",
 		Schema: &Schema{
-			Type:        "array",
+			Type:        SchemaTypes{"array"},
 			UniqueItems: true,
 			Items: (&Schema{
-				Type:        "array",
+				Type:        SchemaTypes{"array"},
 				UniqueItems: true,
 				Items: (&Schema{
-					Type: "object",
+					Type: SchemaTypes{"object"},
 					Properties: Schemas{
 						"key1": NewFloat64Schema().NewRef(),
 					},
@@ -756,7 +850,7 @@ var schemaExamples = []schemaExample{
 	{
 		Title: "OBJECT",
 		Schema: &Schema{
-			Type:     "object",
+			Type:     SchemaTypes{"object"},
 			MaxProps: Uint64Ptr(2),
 			Properties: Schemas{
 				"numberProperty": NewFloat64Schema().NewRef(),
@@ -799,11 +893,48 @@ var schemaExamples = []schemaExample{
 		},
 	},
 	{
+		Title: "OBJECT: MIN AND MAX PROPERTIES",
 		Schema: &Schema{
-			Type: "object",
+			Type:     SchemaTypes{"object"},
+			MinProps: 1,
+			MaxProps: Uint64Ptr(2),
+			Properties: Schemas{
+				"a": NewStringSchema().NewRef(),
+				"b": NewStringSchema().NewRef(),
+				"c": NewStringSchema().NewRef(),
+			},
+			AdditionalProperties: AdditionalProperties{Has: BoolPtr(false)},
+		},
+		Serialization: map[string]interface{}{
+			"type":          "object",
+			"minProperties": 1,
+			"maxProperties": 2,
+			"properties": map[string]interface{}{
+				"a": map[string]interface{}{"type": "string"},
+				"b": map[string]interface{}{"type": "string"},
+				"c": map[string]interface{}{"type": "string"},
+			},
+			"additionalProperties": false,
+		},
+		AllValid: []interface{}{
+			map[string]interface{}{"a": "x"},
+			map[string]interface{}{"a": "x", "b": "y"},
+			map[string]interface{}{"b": "y", "c": "z"},
+		},
+		AllInvalid: []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"a": "x", "b": "y", "c": "z"},
+			// extra keys count, even though they are not allowed
+			map[string]interface{}{"extra": "x"},
+			map[string]interface{}{"a": "x", "b": "y", "extra": "z"},
+		},
+	},
+	{
+		Schema: &Schema{
+			Type: SchemaTypes{"object"},
 			AdditionalProperties: AdditionalProperties{Schema: &SchemaRef{
 				Value: &Schema{
-					Type: "number",
+					Type: SchemaTypes{"number"},
 				},
 			}},
 		},
@@ -828,7 +959,7 @@ var schemaExamples = []schemaExample{
 	},
 	{
 		Schema: &Schema{
-			Type:                 "object",
+			Type:                 SchemaTypes{"object"},
 			AdditionalProperties: AdditionalProperties{Has: BoolPtr(true)},
 		},
 		Serialization: map[string]interface{}{
@@ -1174,10 +1305,9 @@ func testSchemaMultiError(t *testing.T, example schemaMultiErrorExample) func(*t
 var schemaMultiErrorExamples = []schemaMultiErrorExample{
 	{
 		Title: "STRING",
-		Schema: NewStringSchema().
+		Schema: mustWithPattern(NewStringSchema().
 			WithMinLength(2).
-			WithMaxLength(3).
-			WithPattern("^[abc]+$"),
+			WithMaxLength(3), "^[abc]+$"),
 		Values: []interface{}{
 			"f",
 			"foobar",
@@ -1206,8 +1336,7 @@ var schemaMultiErrorExamples = []schemaMultiErrorExample{
 		Schema: NewArraySchema().
 			WithMinItems(2).
 			WithMaxItems(2).
-			WithItems(NewStringSchema().
-				WithPattern("^[abc]+$")),
+			WithItems(mustWithPattern(NewStringSchema(), "^[abc]+$")),
 		Values: []interface{}{
 			[]interface{}{"foo"},
 			[]interface{}{"foo", "bar", "fizz"},
@@ -1256,8 +1385,7 @@ var schemaMultiErrorExamples = []schemaMultiErrorExample{
 				"key1": NewStringSchema(),
 				"key2": NewIntegerSchema(),
 				"key3": NewArraySchema().
-					WithItems(NewStringSchema().
-						WithPattern("^[abc]+$")),
+					WithItems(mustWithPattern(NewStringSchema(), "^[abc]+$")),
 			}),
 		Values: []interface{}{
 			map[string]interface{}{
@@ -1315,13 +1443,35 @@ components:
 func TestValidationFailsOnInvalidPattern(t *testing.T) {
 	schema := Schema{
 		Pattern: "[",
-		Type:    "string",
+		Type:    SchemaTypes{"string"},
 	}
 
 	err := schema.Validate(context.Background())
 	require.Error(t, err)
 }
 
+func TestWithPattern(t *testing.T) {
+	schema, err := NewStringSchema().WithPattern("[unclosed")
+	require.ErrorContains(t, err, "missing closing ]")
+	require.Equal(t, "[unclosed", schema.Pattern)
+
+	schema, err = NewStringSchema().WithPattern("^[abc]+$")
+	require.NoError(t, err)
+	cp, ok := compiledPatterns.Load("^[abc]+$")
+	require.True(t, ok)
+	require.True(t, cp.(*regexp.Regexp).MatchString("abc"))
+	require.NoError(t, schema.VisitJSON("abc"))
+	require.Error(t, schema.VisitJSON("def"))
+}
+
+func mustWithPattern(schema *Schema, pattern string) *Schema {
+	schema, err := schema.WithPattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
 func TestIssue646(t *testing.T) {
 	data := []byte(`
 enum:
@@ -1366,7 +1516,7 @@ enum:
 
 func TestIssue751(t *testing.T) {
 	schema := &Schema{
-		Type:        "array",
+		Type:        SchemaTypes{"array"},
 		UniqueItems: true,
 		Items:       NewStringSchema().NewRef(),
 	}
@@ -1375,3 +1525,387 @@ func TestIssue751(t *testing.T) {
 	require.NoError(t, schema.VisitJSON(validData))
 	require.ErrorContains(t, schema.VisitJSON(invalidData), "duplicate items found")
 }
+
+func TestSchemaExtensionsRoundTrip(t *testing.T) {
+	data := []byte(`{
+  "type": "object",
+  "x-go-type": "uuid.UUID",
+  "x-nullable-keys": {"a": true},
+  "properties": {
+    "id": {"type": "string", "x-order": 1}
+  }
+}`)
+
+	var schema Schema
+	err := json.Unmarshal(data, &schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"x-go-type":       "uuid.UUID",
+		"x-nullable-keys": map[string]interface{}{"a": true},
+	}, schema.Extensions)
+
+	once, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(once))
+
+	var again Schema
+	err = json.Unmarshal(once, &again)
+	require.NoError(t, err)
+	require.Equal(t, schema.Extensions, again.Extensions)
+	require.Equal(t, map[string]interface{}{"x-order": float64(1)}, again.Properties["id"].Value.Extensions)
+
+	twice, err := json.Marshal(again)
+	require.NoError(t, err)
+	require.JSONEq(t, string(once), string(twice))
+}
+
+func TestSchemaValidateReportsPropertyPath(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        addresses:
+          type: array
+          items:
+            type: object
+            properties:
+              street:
+                type: string
+                x_unknown: true
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, `invalid components: schema "User": invalid property "addresses": invalid items: invalid property "street": extra sibling fields: [x_unknown]`)
+}
+
+func TestWithMinimumAndMaximum(t *testing.T) {
+	schema := NewFloat64Schema().WithMinimum(0, true).WithMaximum(10, false)
+	require.Equal(t, Float64Ptr(0), schema.Min)
+	require.True(t, schema.ExclusiveMin)
+	require.Equal(t, Float64Ptr(10), schema.Max)
+	require.False(t, schema.ExclusiveMax)
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"number","minimum":0,"exclusiveMinimum":true,"maximum":10}`, string(data))
+
+	require.Error(t, schema.VisitJSON(0.0))
+	require.NoError(t, schema.VisitJSON(0.5))
+	require.NoError(t, schema.VisitJSON(10.0))
+
+	// OpenAPI 3.1 numeric bounds are replaced
+	schema.ExclusiveMinValue, schema.ExclusiveMaxValue = Float64Ptr(5), Float64Ptr(6)
+	require.Error(t, schema.VisitJSON(5.0))
+	schema = schema.WithMinimum(1, false).WithMaximum(9, true)
+	require.Nil(t, schema.ExclusiveMinValue)
+	require.Nil(t, schema.ExclusiveMaxValue)
+	require.NoError(t, schema.VisitJSON(5.0))
+	require.Error(t, schema.VisitJSON(9.0))
+}
+
+func TestSchemaNumericExclusiveBounds(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			spec := []byte(`
+openapi: ` + version + `
+info: {title: MyAPI, version: "0.1"}
+paths: {}
+components:
+  schemas:
+    Positive:
+      type: number
+      exclusiveMinimum: 0
+    Small:
+      type: number
+      exclusiveMaximum: 1
+`)
+			loader := NewLoader()
+			doc, err := loader.LoadFromData(spec)
+			require.NoError(t, err)
+			require.NoError(t, doc.Validate(loader.Context))
+
+			positive := doc.Components.Schemas["Positive"].Value
+			require.Equal(t, Float64Ptr(0), positive.ExclusiveMinValue)
+			require.False(t, positive.ExclusiveMin)
+			require.EqualError(t, positive.VisitJSON(0.0), `number must be more than 0
+Schema:
+  {
+    "exclusiveMinimum": 0,
+    "type": "number"
+  }
+
+Value:
+  0
+`)
+			require.NoError(t, positive.VisitJSON(0.001))
+
+			small := doc.Components.Schemas["Small"].Value
+			require.Error(t, small.VisitJSON(1.0))
+			require.NoError(t, small.VisitJSON(0.999))
+
+			data, err := json.Marshal(positive)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"type":"number","exclusiveMinimum":0}`, string(data))
+		})
+	}
+
+	var schema Schema
+	err := json.Unmarshal([]byte(`{"type":"number","minimum":0,"exclusiveMinimum":true}`), &schema)
+	require.NoError(t, err)
+	require.True(t, schema.ExclusiveMin)
+	require.Nil(t, schema.ExclusiveMinValue)
+
+	err = json.Unmarshal([]byte(`{"exclusiveMinimum":"0"}`), &schema)
+	require.EqualError(t, err, "invalid exclusiveMinimum: json: cannot unmarshal string into Go value of type float64")
+}
+
+func TestSchemaPreservesLargeIntegers(t *testing.T) {
+	spec := []byte(`{
+  "openapi": "3.0.3",
+  "info": {"title": "MyAPI", "version": "0.1"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "Id": {
+        "type": "integer",
+        "format": "int64",
+        "enum": [9007199254740993, 1],
+        "default": 9007199254740993,
+        "example": 9007199254740993
+      },
+      "Pet": {
+        "type": "object",
+        "example": {"id": 9007199254740993, "ratio": 0.5}
+      }
+    }
+  }
+}`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	schema := doc.Components.Schemas["Id"].Value
+	require.Equal(t, []interface{}{json.Number("9007199254740993"), float64(1)}, schema.Enum)
+	require.Equal(t, json.Number("9007199254740993"), schema.Default)
+	require.Equal(t, json.Number("9007199254740993"), schema.Example)
+	example := doc.Components.Schemas["Pet"].Value.Example
+	require.Equal(t, map[string]interface{}{"id": json.Number("9007199254740993"), "ratio": 0.5}, example)
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"default":9007199254740993`)
+
+	require.NoError(t, schema.VisitJSON(json.Number("9007199254740993")))
+	require.Error(t, schema.VisitJSON(json.Number("9007199254740992")))
+	require.NoError(t, schema.VisitJSON(float64(1)))
+
+	// Decoded parameters are compared numerically
+	require.NoError(t, schema.VisitJSON(int64(9007199254740993)))
+	require.Error(t, schema.VisitJSON(int64(9007199254740992)))
+	require.NoError(t, schema.VisitJSON(uint64(9007199254740993)))
+	require.NoError(t, schema.VisitJSON(float64(9007199254740993)))
+	require.NoError(t, schema.VisitJSON(json.Number("9.007199254740993e15")))
+	require.Error(t, schema.VisitJSON(float64(2)))
+}
+
+func TestSchemaMultiErrorsOrderedByProperty(t *testing.T) {
+	schema := NewObjectSchema().
+		WithProperty("zebra", NewStringSchema()).
+		WithProperty("apple", NewIntegerSchema()).
+		WithProperty("mango", NewBoolSchema())
+	value := map[string]interface{}{
+		"zebra": 1.0,
+		"mango": "yes",
+		"apple": "one",
+	}
+
+	for i := 0; i < 10; i++ {
+		err := schema.VisitJSON(value, MultiErrors())
+		var me MultiError
+		require.ErrorAs(t, err, &me)
+		require.Len(t, me, 3)
+		var fields []string
+		for _, e := range me {
+			var schemaErr *SchemaError
+			require.ErrorAs(t, e, &schemaErr)
+			fields = append(fields, schemaErr.JSONPointer()[0])
+		}
+		require.Equal(t, []string{"apple", "mango", "zebra"}, fields)
+	}
+}
+
+func TestSchemaValidateExample(t *testing.T) {
+	schema := &Schema{
+		Type:       SchemaTypes{TypeObject},
+		Properties: Schemas{"age": &SchemaRef{Value: &Schema{Type: SchemaTypes{TypeInteger}, Example: "forty-two"}}},
+	}
+
+	err := schema.Validate(context.Background())
+	require.ErrorContains(t, err, `invalid property "age": invalid example: `)
+
+	err = schema.Validate(context.Background(), DisableExamplesValidation())
+	require.NoError(t, err)
+}
+
+func TestSchemaAdditionalPropertiesBuilders(t *testing.T) {
+	schema := NewObjectSchema().
+		WithAdditionalProperties(NewStringSchema()).
+		WithAdditionalPropertiesAllowed(false)
+	require.Equal(t, AdditionalProperties{Has: BoolPtr(false)}, schema.AdditionalProperties)
+	require.NoError(t, schema.Validate(context.Background()))
+
+	schema.WithAdditionalProperties(NewStringSchema())
+	require.Nil(t, schema.AdditionalProperties.Has)
+	require.Equal(t, NewStringSchema(), schema.AdditionalProperties.Schema.Value)
+	require.NoError(t, schema.Validate(context.Background()))
+
+	schema.AdditionalProperties.Has = BoolPtr(true)
+	err := schema.Validate(context.Background())
+	require.EqualError(t, err, "additionalProperties are set to both boolean and schema")
+}
+
+func TestSchemaMinMaxPropertiesErrors(t *testing.T) {
+	schema := NewObjectSchema().
+		WithMinProperties(1).
+		WithMaxProperties(2).
+		WithProperty("a", NewStringSchema()).
+		WithoutAdditionalProperties()
+
+	err := schema.VisitJSON(map[string]interface{}{})
+	require.ErrorContains(t, err, "there must be at least 1 properties")
+
+	err = schema.VisitJSON(map[string]interface{}{"a": "x", "b": "y", "c": "z"}, MultiErrors())
+	require.Error(t, err)
+	var fields []string
+	for _, e := range err.(MultiError) {
+		fields = append(fields, e.(*SchemaError).SchemaField)
+	}
+	require.Equal(t, []string{"maxProperties", "properties", "properties"}, fields)
+}
+
+func TestSchemaErrorNestedJSONPointer(t *testing.T) {
+	address := NewObjectSchema().WithProperty("zipCode", NewStringSchema().WithMinLength(5))
+	value := map[string]interface{}{
+		"user": map[string]interface{}{
+			"address": map[string]interface{}{"zipCode": "123"},
+		},
+	}
+
+	for name, user := range map[string]*Schema{
+		"properties": NewObjectSchema().WithProperty("address", address),
+		"allOf":      NewObjectSchema().WithProperty("address", NewAllOfSchema(address)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			schema := NewObjectSchema().WithProperty("user", user)
+			for _, opts := range [][]SchemaValidationOption{nil, {MultiErrors()}} {
+				err := schema.VisitJSON(value, opts...)
+				require.ErrorContains(t, err, `Error at "/user/address/zipCode": minimum string length is 5`)
+
+				var schemaErr *SchemaError
+				require.ErrorAs(t, err, &schemaErr)
+				for schemaErr.SchemaField != "minLength" {
+					require.ErrorAs(t, schemaErr.Origin, &schemaErr)
+				}
+				require.Equal(t, []string{"user", "address", "zipCode"}, schemaErr.JSONPointer())
+			}
+		})
+	}
+}
+
+func TestSchemaErrorArrayItemsJSONPointer(t *testing.T) {
+	schema := NewObjectSchema().WithProperty("tags", NewArraySchema().WithItems(NewStringSchema().WithMaxLength(3)))
+	value := map[string]interface{}{"tags": []interface{}{"abc", "abcd", "ab", "abcdef"}}
+
+	err := schema.VisitJSON(value)
+	require.ErrorContains(t, err, `Error at "/tags/1": maximum string length is 3`)
+
+	err = schema.VisitJSON(value, MultiErrors())
+	require.Error(t, err)
+	var pointers [][]string
+	for _, e := range err.(MultiError) {
+		pointers = append(pointers, e.(*SchemaError).JSONPointer())
+	}
+	require.Equal(t, [][]string{{"tags", "1"}, {"tags", "3"}}, pointers)
+}
+
+func TestSchemaRefBuilders(t *testing.T) {
+	schema := NewStringSchema()
+
+	ref := NewSchemaRef("#/components/schemas/Old", schema).WithRef("#/components/schemas/Pet")
+	require.Equal(t, &SchemaRef{Ref: "#/components/schemas/Pet"}, ref)
+
+	ref = NewSchemaRef("#/components/schemas/Pet", schema).WithValue(NewIntegerSchema())
+	require.Equal(t, &SchemaRef{Value: NewIntegerSchema()}, ref)
+
+	data, err := json.Marshal(ref.WithRef("#/components/schemas/Pet"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"$ref":"#/components/schemas/Pet"}`, string(data))
+}
+
+func TestSchemaVisitJSONNumber(t *testing.T) {
+	require.NoError(t, NewIntegerSchema().VisitJSON(json.Number("42")))
+	require.NoError(t, NewFloat64Schema().VisitJSON(json.Number("3.14")))
+	require.ErrorContains(t, NewIntegerSchema().VisitJSON(json.Number("3.14")), "value must be an integer")
+	require.ErrorContains(t, NewIntegerSchema().WithMax(10).VisitJSON(json.Number("42")), "number must be at most 10")
+	require.ErrorContains(t, NewStringSchema().VisitJSON(json.Number("42")), `value must be a string`)
+
+	schema := NewObjectSchema().
+		WithProperty("id", NewInt64Schema()).
+		WithProperty("ratio", NewFloat64Schema()).
+		WithProperty("counts", NewArraySchema().WithItems(NewInt32Schema()))
+	decoder := json.NewDecoder(strings.NewReader(`{"id": 42, "ratio": 3.14, "counts": [1, 2]}`))
+	decoder.UseNumber()
+	var value interface{}
+	require.NoError(t, decoder.Decode(&value))
+	require.NoError(t, schema.VisitJSON(value))
+}
+
+func TestSchemaVisitJSONGoNumbers(t *testing.T) {
+	integer := NewIntegerSchema().WithMin(1).WithMax(100)
+	number := NewFloat64Schema().WithMin(0.5).WithMax(1.5)
+
+	for _, value := range []interface{}{
+		int(42), int8(42), int16(42), int32(42), int64(42),
+		uint(42), uint8(42), uint16(42), uint32(42), uint64(42),
+		float32(42), float64(42),
+	} {
+		require.NoErrorf(t, integer.VisitJSON(value), "%T", value)
+		require.ErrorContainsf(t, NewStringSchema().VisitJSON(value), "value must be a string", "%T", value)
+	}
+	for _, value := range []interface{}{int8(-1), int64(1000), uint8(200), uint64(1000)} {
+		require.Errorf(t, integer.VisitJSON(value), "%T", value)
+	}
+
+	require.NoError(t, number.VisitJSON(float32(1.25)))
+	require.Error(t, number.VisitJSON(float32(2.5)))
+	require.ErrorContains(t, NewIntegerSchema().VisitJSON(float32(1.25)), "value must be an integer")
+	require.ErrorIs(t, number.VisitJSON(float32(math.NaN())), ErrSchemaInputNaN)
+	require.ErrorIs(t, number.VisitJSON(float32(math.Inf(1))), ErrSchemaInputInf)
+
+	// Enum values are compared as float64
+	for _, enum := range []*Schema{
+		NewIntegerSchema().WithEnum(float64(1), float64(2), float64(3)),
+		NewIntegerSchema().WithEnum(1, 2, 3),
+	} {
+		for _, value := range []interface{}{
+			int(2), int8(2), int16(2), int32(2), int64(2),
+			uint(2), uint8(2), uint16(2), uint32(2), uint64(2),
+			float32(2), float64(2),
+		} {
+			require.NoErrorf(t, enum.VisitJSON(value), "%T", value)
+		}
+		require.ErrorContains(t, enum.VisitJSON(int8(4)), "value is not one of the allowed values")
+		require.ErrorContains(t, enum.VisitJSON(float32(2.5)), "value is not one of the allowed values")
+	}
+}
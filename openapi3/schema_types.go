@@ -0,0 +1,79 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TypeNull is the type of null values in OpenAPI 3.1 schemas, e.g. `type: [string, "null"]`.
+const TypeNull = "null"
+
+// SchemaTypes is the type of a Schema: a single type in OpenAPI 3.0
+// and possibly several of them in OpenAPI 3.1.
+// No types allows values of any type.
+type SchemaTypes []string
+
+// Is tells whether types is the single type t.
+func (types SchemaTypes) Is(t string) bool {
+	return len(types) == 1 && types[0] == t
+}
+
+// Includes tells whether t is one of types.
+func (types SchemaTypes) Includes(t string) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Permits tells whether values of type t are allowed, which is the case when no types are set.
+func (types SchemaTypes) Permits(t string) bool {
+	return len(types) == 0 || types.Includes(t)
+}
+
+func (types SchemaTypes) String() string {
+	return strings.Join(types, ", ")
+}
+
+// MarshalJSON returns the JSON encoding of SchemaTypes:
+// a string for a single type, as in OpenAPI 3.0, or an array.
+func (types SchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(types) == 1 {
+		return json.Marshal(types[0])
+	}
+	return json.Marshal([]string(types))
+}
+
+// MarshalYAML returns the YAML encoding of SchemaTypes.
+func (types SchemaTypes) MarshalYAML() (interface{}, error) {
+	if len(types) == 1 {
+		return types[0], nil
+	}
+	return []string(types), nil
+}
+
+// UnmarshalJSON sets SchemaTypes to a copy of data, either a string or an array of strings.
+func (types *SchemaTypes) UnmarshalJSON(data []byte) error {
+	var typ string
+	if err := json.Unmarshal(data, &typ); err == nil {
+		*types = nil
+		if typ != "" {
+			*types = SchemaTypes{typ}
+		}
+		return nil
+	}
+	var x []string
+	if err := json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+	*types = x
+	return nil
+}
+
+// WithTypes sets the types of the schema, e.g. WithTypes(TypeString, TypeNull).
+func (schema *Schema) WithTypes(types ...string) *Schema {
+	schema.Type = types
+	return schema
+}
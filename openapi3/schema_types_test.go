@@ -0,0 +1,46 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/invopop/yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaTypesMarshaling(t *testing.T) {
+	schema := NewSchema().WithTypes(TypeString, TypeNull)
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":["string","null"]}`, string(data))
+
+	data, err = json.Marshal(NewStringSchema())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"string"}`, string(data))
+
+	var loaded Schema
+	err = json.Unmarshal([]byte(`{"type":["integer","null"]}`), &loaded)
+	require.NoError(t, err)
+	require.Equal(t, SchemaTypes{TypeInteger, TypeNull}, loaded.Type)
+
+	err = yaml.Unmarshal([]byte("type: boolean\n"), &loaded)
+	require.NoError(t, err)
+	require.Equal(t, SchemaTypes{TypeBoolean}, loaded.Type)
+}
+
+func TestSchemaTypesVisitJSON(t *testing.T) {
+	schema := NewSchema().WithTypes(TypeString, TypeNull)
+	require.NoError(t, schema.Validate(context.Background()))
+
+	require.NoError(t, schema.VisitJSON("abc"))
+	require.NoError(t, schema.VisitJSON(nil))
+	err := schema.VisitJSON(42.0)
+	require.ErrorContains(t, err, "value must be one of string, null")
+
+	schema = NewSchema().WithTypes(TypeInteger, TypeString)
+	require.NoError(t, schema.VisitJSON(42.0))
+	require.NoError(t, schema.VisitJSON("42"))
+	require.Error(t, schema.VisitJSON(4.2))
+	require.Error(t, schema.VisitJSON(nil))
+}
@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"sync"
 )
 
@@ -12,14 +13,22 @@ type schemaValidationSettings struct {
 	multiError                  bool
 	asreq, asrep                bool // exclusive (XOR) fields
 	formatValidationEnabled     bool
+	formatAssertionsDisabled    bool
 	patternValidationDisabled   bool
 	readOnlyValidationDisabled  bool
 	writeOnlyValidationDisabled bool
+	coerce                      bool
+	coercedValue                *interface{}
 
 	onceSettingDefaults sync.Once
 	defaultsSet         func()
 
 	customizeMessageError func(err *SchemaError) string
+
+	// dynamicScope lists the schemas being visited, outermost first, to resolve $dynamicRef.
+	dynamicScope []*Schema
+
+	ctx context.Context
 }
 
 // FailFast returns schema validation errors quicker.
@@ -44,6 +53,12 @@ func EnableFormatValidation() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.formatValidationEnabled = true }
 }
 
+// DisableFormatAssertions setting makes VisitJSON ignore the format of schemas,
+// both the ones it knows (e.g. "date" or "int32") and the unknown ones. It overrides EnableFormatValidation.
+func DisableFormatAssertions() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.formatAssertionsDisabled = true }
+}
+
 // DisablePatternValidation setting makes Validate not return an error when validating patterns that are not supported by the Go regexp engine.
 func DisablePatternValidation() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.patternValidationDisabled = true }
@@ -70,6 +85,24 @@ func SetSchemaErrorMessageCustomizer(f func(err *SchemaError) string) SchemaVali
 	return func(s *schemaValidationSettings) { s.customizeMessageError = f }
 }
 
+// WithContext makes validation abort early once the given context is done,
+// in which case the context's error is returned.
+func WithContext(ctx context.Context) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.ctx = ctx }
+}
+
+// WithCoerce makes VisitJSON convert string values to the type declared by their schema
+// (integer, number, boolean, or null for nullable schemas) before validating them.
+// Values of schemas using oneOf, anyOf or allOf are left as is.
+func WithCoerce(enabled bool) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.coerce = enabled }
+}
+
+// CoercedValue makes VisitJSON store the validated value, after coercion (see WithCoerce), in value.
+func CoercedValue(value *interface{}) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.coercedValue = value }
+}
+
 func newSchemaValidationSettings(opts ...SchemaValidationOption) *schemaValidationSettings {
 	settings := &schemaValidationSettings{}
 	for _, opt := range opts {
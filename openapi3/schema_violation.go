@@ -0,0 +1,70 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SchemaViolation is a JSON-serializable description of a SchemaError,
+// suitable for the errors of an application/problem+json response.
+type SchemaViolation struct {
+	// Keyword is the schema keyword that failed validation, e.g. "maxLength".
+	Keyword string
+	// InstancePath is the JSON Pointer to the value that failed validation, e.g. "/name".
+	InstancePath string
+	// SchemaPath is the JSON Pointer to the keyword that failed validation, e.g. "#/properties/name/maxLength".
+	SchemaPath string
+	// Message is a human-readable message describing the violation.
+	Message string
+}
+
+var _ json.Marshaler = SchemaViolation{}
+
+// MarshalJSON returns the JSON encoding of SchemaViolation.
+func (violation SchemaViolation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"keyword":      violation.Keyword,
+		"instancePath": violation.InstancePath,
+		"schemaPath":   violation.SchemaPath,
+		"message":      violation.Message,
+	})
+}
+
+// AsSchemaViolation converts err to a SchemaViolation.
+func (err *SchemaError) AsSchemaViolation() SchemaViolation {
+	if origin, ok := err.Origin.(*SchemaError); ok && (len(origin.reversePath) > 0 || len(origin.reverseSchemaPath) > 0) {
+		// The origin is located from the same root, as in Error()
+		return origin.AsSchemaViolation()
+	}
+
+	message := err.Reason
+	if err.Origin != nil {
+		message = err.Origin.Error()
+	} else if message == "" {
+		message = `doesn't match schema "` + err.SchemaField + `"`
+	}
+
+	schemaPath := make([]string, 0, len(err.reverseSchemaPath)+1)
+	for i := len(err.reverseSchemaPath) - 1; i >= 0; i-- {
+		schemaPath = append(schemaPath, err.reverseSchemaPath[i])
+	}
+	if err.SchemaField != "" {
+		schemaPath = append(schemaPath, err.SchemaField)
+	}
+
+	return SchemaViolation{
+		Keyword:      err.SchemaField,
+		InstancePath: jsonPointer(err.JSONPointer()),
+		SchemaPath:   "#" + jsonPointer(schemaPath),
+		Message:      message,
+	}
+}
+
+func jsonPointer(tokens []string) string {
+	var buf strings.Builder
+	for _, token := range tokens {
+		buf.WriteByte('/')
+		buf.WriteString(escapeJSONPointerToken(token))
+	}
+	return buf.String()
+}
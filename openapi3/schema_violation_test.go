@@ -0,0 +1,96 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaErrorAsSchemaViolation(t *testing.T) {
+	schema := NewObjectSchema().
+		WithProperty("name", NewStringSchema().WithMaxLength(3)).
+		WithProperty("tags", NewArraySchema().WithItems(NewStringSchema())).
+		WithProperty("nick", NewAllOfSchema(NewStringSchema(), NewStringSchema().WithMaxLength(3))).
+		WithAdditionalProperties(NewIntegerSchema())
+	schema.Required = []string{"id"}
+
+	tests := []struct {
+		value    map[string]interface{}
+		expected SchemaViolation
+	}{
+		{
+			value: map[string]interface{}{"id": 1.0, "name": "abcd"},
+			expected: SchemaViolation{
+				Keyword:      "maxLength",
+				InstancePath: "/name",
+				SchemaPath:   "#/properties/name/maxLength",
+				Message:      "maximum string length is 3",
+			},
+		},
+		{
+			value: map[string]interface{}{"id": 1.0, "tags": []interface{}{"a", 2.0}},
+			expected: SchemaViolation{
+				Keyword:      "type",
+				InstancePath: "/tags/1",
+				SchemaPath:   "#/properties/tags/items/type",
+				Message:      "value must be a string",
+			},
+		},
+		{
+			value: map[string]interface{}{"id": 1.0, "a/b": "c"},
+			expected: SchemaViolation{
+				Keyword:      "type",
+				InstancePath: "/a~1b",
+				SchemaPath:   "#/additionalProperties/type",
+				Message:      "value must be an integer",
+			},
+		},
+		{
+			value: map[string]interface{}{"id": 1.0, "nick": "abcd"},
+			expected: SchemaViolation{
+				Keyword:      "maxLength",
+				InstancePath: "/nick",
+				SchemaPath:   "#/properties/nick/allOf/1/maxLength",
+				Message:      "maximum string length is 3",
+			},
+		},
+		{
+			value: map[string]interface{}{},
+			expected: SchemaViolation{
+				Keyword:      "required",
+				InstancePath: "/id",
+				SchemaPath:   "#/required",
+				Message:      `property "id" is missing`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expected.SchemaPath, func(t *testing.T) {
+			err := schema.VisitJSON(test.value)
+			var schemaErr *SchemaError
+			require.True(t, errors.As(err, &schemaErr))
+			require.Equal(t, test.expected, schemaErr.AsSchemaViolation())
+		})
+	}
+	// Composed schemas are located at the failing subschema
+	err := NewAllOfSchema(NewIntegerSchema(), NewIntegerSchema().WithMax(3)).VisitJSON(4.0)
+	var schemaErr *SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	violation := schemaErr.AsSchemaViolation()
+	require.Equal(t, "maximum", violation.Keyword)
+	require.Equal(t, "", violation.InstancePath)
+	require.Equal(t, "#/allOf/1/maximum", violation.SchemaPath)
+}
+
+func TestSchemaViolationMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(SchemaViolation{
+		Keyword:      "maxLength",
+		InstancePath: "/name",
+		SchemaPath:   "#/properties/name/maxLength",
+		Message:      "maximum string length is 3",
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"keyword":"maxLength","instancePath":"/name","schemaPath":"#/properties/name/maxLength","message":"maximum string length is 3"}`, string(data))
+}
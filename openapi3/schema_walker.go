@@ -0,0 +1,178 @@
+package openapi3
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// walkSchemas calls w on each schema of doc, located by its JSON Pointer.
+// Schemas reachable from several locations are only visited once.
+func (doc *T) walkSchemas(w *schemaWalker) {
+	if components := doc.Components; components != nil {
+		for _, name := range sortedMapKeys(components.Schemas) {
+			w.schemaRef("/components/schemas/"+escapeJSONPointerToken(name), components.Schemas[name])
+		}
+	}
+	doc.walkSchemaUsers(w)
+}
+
+// walkSchemaUsers calls w on each schema of doc outside of the schemas of its components:
+// the ones of the other components, of the paths and of the webhooks.
+func (doc *T) walkSchemaUsers(w *schemaWalker) {
+	if components := doc.Components; components != nil {
+		for _, name := range sortedMapKeys(components.Parameters) {
+			if p := components.Parameters[name]; p != nil && p.Value != nil {
+				w.parameter("/components/parameters/"+escapeJSONPointerToken(name), p.Value)
+			}
+		}
+		w.headers("/components/headers", components.Headers)
+		for _, name := range sortedMapKeys(components.RequestBodies) {
+			if r := components.RequestBodies[name]; r != nil && r.Value != nil {
+				w.content("/components/requestBodies/"+escapeJSONPointerToken(name)+"/content", r.Value.Content)
+			}
+		}
+		w.responses("/components/responses", components.Responses)
+		w.callbacks("/components/callbacks", components.Callbacks)
+	}
+
+	for _, path := range sortedMapKeys(doc.Paths) {
+		w.pathItem("/paths/"+escapeJSONPointerToken(path), doc.Paths[path])
+	}
+	for _, name := range sortedMapKeys(doc.Webhooks) {
+		w.pathItem("/webhooks/"+escapeJSONPointerToken(name), doc.Webhooks[name])
+	}
+}
+
+type schemaWalker struct {
+	// unresolved is called, if set, on each schema reference without a value.
+	unresolved func(pointer, ref string)
+	// reference is called, if set, on each schema reference.
+	reference func(pointer, ref string)
+	// visit is called, if set, on each schema.
+	visit   func(pointer string, schema *Schema)
+	visited map[*Schema]struct{}
+}
+
+func (w *schemaWalker) schemaRef(pointer string, schemaRef *SchemaRef) {
+	if schemaRef == nil {
+		return
+	}
+	if schemaRef.Ref != "" && w.reference != nil {
+		w.reference(pointer, schemaRef.Ref)
+	}
+	if schemaRef.Value == nil {
+		if schemaRef.Ref != "" {
+			if w.unresolved != nil {
+				w.unresolved(pointer, schemaRef.Ref)
+			}
+		}
+		return
+	}
+	schema := schemaRef.Value
+	if _, ok := w.visited[schema]; ok {
+		return
+	}
+	if w.visited == nil {
+		w.visited = make(map[*Schema]struct{})
+	}
+	w.visited[schema] = struct{}{}
+	if w.visit != nil {
+		w.visit(pointer, schema)
+	}
+
+	for i, v := range schema.OneOf {
+		w.schemaRef(pointer+"/oneOf/"+strconv.Itoa(i), v)
+	}
+	for i, v := range schema.AnyOf {
+		w.schemaRef(pointer+"/anyOf/"+strconv.Itoa(i), v)
+	}
+	for i, v := range schema.AllOf {
+		w.schemaRef(pointer+"/allOf/"+strconv.Itoa(i), v)
+	}
+	w.schemaRef(pointer+"/not", schema.Not)
+	w.schemaRef(pointer+"/items", schema.Items)
+	for _, name := range sortedMapKeys(schema.Properties) {
+		w.schemaRef(pointer+"/properties/"+escapeJSONPointerToken(name), schema.Properties[name])
+	}
+	w.schemaRef(pointer+"/additionalProperties", schema.AdditionalProperties.Schema)
+}
+
+func (w *schemaWalker) content(pointer string, content Content) {
+	for _, mime := range sortedMapKeys(content) {
+		if mediaType := content[mime]; mediaType != nil {
+			w.schemaRef(pointer+"/"+escapeJSONPointerToken(mime)+"/schema", mediaType.Schema)
+		}
+	}
+}
+
+func (w *schemaWalker) parameter(pointer string, parameter *Parameter) {
+	w.schemaRef(pointer+"/schema", parameter.Schema)
+	w.content(pointer+"/content", parameter.Content)
+}
+
+func (w *schemaWalker) parameters(pointer string, parameters Parameters) {
+	for i, p := range parameters {
+		if p != nil && p.Value != nil {
+			w.parameter(pointer+"/"+strconv.Itoa(i), p.Value)
+		}
+	}
+}
+
+func (w *schemaWalker) headers(pointer string, headers Headers) {
+	for _, name := range sortedMapKeys(headers) {
+		if h := headers[name]; h != nil && h.Value != nil {
+			w.parameter(pointer+"/"+escapeJSONPointerToken(name), &h.Value.Parameter)
+		}
+	}
+}
+
+func (w *schemaWalker) responses(pointer string, responses Responses) {
+	for _, code := range sortedMapKeys(responses) {
+		if r := responses[code]; r != nil && r.Value != nil {
+			location := pointer + "/" + escapeJSONPointerToken(code)
+			w.headers(location+"/headers", r.Value.Headers)
+			w.content(location+"/content", r.Value.Content)
+		}
+	}
+}
+
+func (w *schemaWalker) callbacks(pointer string, callbacks Callbacks) {
+	for _, name := range sortedMapKeys(callbacks) {
+		if cb := callbacks[name]; cb != nil && cb.Value != nil {
+			for _, expression := range sortedMapKeys(*cb.Value) {
+				w.pathItem(pointer+"/"+escapeJSONPointerToken(name)+"/"+escapeJSONPointerToken(expression), (*cb.Value)[expression])
+			}
+		}
+	}
+}
+
+func (w *schemaWalker) pathItem(pointer string, pathItem *PathItem) {
+	if pathItem == nil {
+		return
+	}
+	w.parameters(pointer+"/parameters", pathItem.Parameters)
+	operations := pathItem.Operations()
+	for _, method := range sortedMapKeys(operations) {
+		operation := operations[method]
+		location := pointer + "/" + strings.ToLower(method)
+		w.parameters(location+"/parameters", operation.Parameters)
+		if r := operation.RequestBody; r != nil && r.Value != nil {
+			w.content(location+"/requestBody/content", r.Value.Content)
+		}
+		w.responses(location+"/responses", operation.Responses)
+		w.callbacks(location+"/callbacks", operation.Callbacks)
+	}
+}
+
+// sortedMapKeys returns the sorted keys of m, a map with string keys.
+func sortedMapKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.String())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,164 @@
+package openapi3
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SchemasMergeStrategy resolves the conflicts met by Schemas.Merge.
+type SchemasMergeStrategy interface {
+	// MergeSchemas returns the schema to keep under name when both sides define one.
+	MergeSchemas(name string, left, right *SchemaRef) (*SchemaRef, error)
+}
+
+// SchemasMergeStrategyFunc is a SchemasMergeStrategy defined by a function.
+type SchemasMergeStrategyFunc func(name string, left, right *SchemaRef) (*SchemaRef, error)
+
+// MergeSchemas calls f.
+func (f SchemasMergeStrategyFunc) MergeSchemas(name string, left, right *SchemaRef) (*SchemaRef, error) {
+	return f(name, left, right)
+}
+
+// Built-in strategies for Schemas.Merge
+var (
+	// ErrorOnConflict fails on schemas defined on both sides, unless they are equal.
+	ErrorOnConflict SchemasMergeStrategy = SchemasMergeStrategyFunc(func(name string, left, right *SchemaRef) (*SchemaRef, error) {
+		return nil, fmt.Errorf("schema %q is defined on both sides", name)
+	})
+
+	// PreferLeft keeps the schemas being merged into.
+	PreferLeft SchemasMergeStrategy = SchemasMergeStrategyFunc(func(name string, left, right *SchemaRef) (*SchemaRef, error) {
+		return left, nil
+	})
+
+	// PreferRight replaces the schemas being merged into.
+	PreferRight SchemasMergeStrategy = SchemasMergeStrategyFunc(func(name string, left, right *SchemaRef) (*SchemaRef, error) {
+		return right, nil
+	})
+
+	// DeepMerge combines both schemas: properties are merged recursively,
+	// required properties of both sides are required and
+	// other fields are taken from the left schema when set there.
+	// References to different schemas cannot be merged.
+	DeepMerge SchemasMergeStrategy = SchemasMergeStrategyFunc(deepMergeSchemaRefs)
+)
+
+// Merge adds the schemas of other to schemas, calling strategy (ErrorOnConflict if nil)
+// for the names found on both sides whose schemas differ.
+// schemas is left untouched when an error is returned.
+func (schemas Schemas) Merge(other Schemas, strategy SchemasMergeStrategy) error {
+	if strategy == nil {
+		strategy = ErrorOnConflict
+	}
+
+	names := make([]string, 0, len(other))
+	for name := range other {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	merged := make(Schemas, len(names))
+	for _, name := range names {
+		right := other[name]
+		left, ok := schemas[name]
+		if !ok || schemaRefsEqual(left, right) {
+			merged[name] = right
+			continue
+		}
+		v, err := strategy.MergeSchemas(name, left, right)
+		if err != nil {
+			return err
+		}
+		merged[name] = v
+	}
+
+	for name, v := range merged {
+		schemas[name] = v
+	}
+	return nil
+}
+
+func schemaRefsEqual(left, right *SchemaRef) bool {
+	if left == right {
+		return true
+	}
+	if left == nil || right == nil {
+		return false
+	}
+	if left.Ref != "" || right.Ref != "" {
+		return left.Ref == right.Ref
+	}
+	return reflect.DeepEqual(left.Value, right.Value)
+}
+
+func deepMergeSchemaRefs(name string, left, right *SchemaRef) (*SchemaRef, error) {
+	if schemaRefsEqual(left, right) {
+		return left, nil
+	}
+	if left == nil {
+		return right, nil
+	}
+	if right == nil {
+		return left, nil
+	}
+	if left.Ref != "" || right.Ref != "" {
+		return nil, fmt.Errorf("schema %q references %q and %q", name, left.Ref, right.Ref)
+	}
+	if left.Value == nil || right.Value == nil {
+		return nil, fmt.Errorf("schema %q has not been resolved", name)
+	}
+
+	merged := *left.Value
+	r := right.Value
+	if len(merged.Type) == 0 {
+		merged.Type = r.Type
+	} else if len(r.Type) != 0 && !reflect.DeepEqual(r.Type, merged.Type) {
+		return nil, fmt.Errorf("schema %q has types %q and %q", name, merged.Type, r.Type)
+	}
+	if merged.Format == "" {
+		merged.Format = r.Format
+	}
+	if merged.Title == "" {
+		merged.Title = r.Title
+	}
+	if merged.Description == "" {
+		merged.Description = r.Description
+	}
+
+	required := make(map[string]struct{}, len(merged.Required))
+	merged.Required = append([]string(nil), merged.Required...)
+	for _, property := range merged.Required {
+		required[property] = struct{}{}
+	}
+	for _, property := range r.Required {
+		if _, ok := required[property]; !ok {
+			merged.Required = append(merged.Required, property)
+		}
+	}
+
+	if len(r.Properties) != 0 {
+		properties := make(Schemas, len(merged.Properties)+len(r.Properties))
+		for k, v := range merged.Properties {
+			properties[k] = v
+		}
+		if err := properties.Merge(r.Properties, SchemasMergeStrategyFunc(func(property string, left, right *SchemaRef) (*SchemaRef, error) {
+			return deepMergeSchemaRefs(name+"."+property, left, right)
+		})); err != nil {
+			return nil, err
+		}
+		merged.Properties = properties
+	}
+
+	if merged.Items == nil {
+		merged.Items = r.Items
+	} else if r.Items != nil {
+		items, err := deepMergeSchemaRefs(name+"[]", merged.Items, r.Items)
+		if err != nil {
+			return nil, err
+		}
+		merged.Items = items
+	}
+
+	return &SchemaRef{Value: &merged}, nil
+}
@@ -0,0 +1,71 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemasMerge(t *testing.T) {
+	newLeft := func() Schemas {
+		pet := NewObjectSchema().
+			WithProperty("name", NewStringSchema()).
+			WithProperty("owner", NewObjectSchema().WithProperty("id", NewIntegerSchema()))
+		pet.Required = []string{"name"}
+		return Schemas{
+			"Error": NewStringSchema().NewRef(),
+			"Pet":   pet.NewRef(),
+		}
+	}
+	pet := NewObjectSchema().
+		WithProperty("tag", NewStringSchema()).
+		WithProperty("owner", NewObjectSchema().WithProperty("name", NewStringSchema()))
+	pet.Required = []string{"tag", "name"}
+	right := Schemas{
+		"Error": NewStringSchema().NewRef(),
+		"Pet":   pet.NewRef(),
+		"Store": NewObjectSchema().NewRef(),
+	}
+
+	t.Run("ErrorOnConflict", func(t *testing.T) {
+		left := newLeft()
+		err := left.Merge(right, nil)
+		require.EqualError(t, err, `schema "Pet" is defined on both sides`)
+		require.Equal(t, newLeft(), left)
+	})
+
+	t.Run("PreferLeft", func(t *testing.T) {
+		left := newLeft()
+		require.NoError(t, left.Merge(right, PreferLeft))
+		require.Equal(t, newLeft()["Pet"], left["Pet"])
+		require.Same(t, right["Store"], left["Store"])
+	})
+
+	t.Run("PreferRight", func(t *testing.T) {
+		left := newLeft()
+		require.NoError(t, left.Merge(right, PreferRight))
+		require.Same(t, right["Pet"], left["Pet"])
+		require.Same(t, right["Store"], left["Store"])
+	})
+
+	t.Run("DeepMerge", func(t *testing.T) {
+		left := newLeft()
+		original := left["Pet"].Value
+		require.NoError(t, left.Merge(right, DeepMerge))
+		pet := left["Pet"].Value
+		require.Equal(t, []string{"name", "tag"}, pet.Required)
+		require.ElementsMatch(t, []string{"name", "owner", "tag"}, schemaNames(pet.Properties))
+		require.ElementsMatch(t, []string{"id", "name"}, schemaNames(pet.Properties["owner"].Value.Properties))
+		require.Same(t, right["Store"], left["Store"])
+
+		// The merged schemas are not modified
+		require.Equal(t, newLeft()["Pet"].Value, original)
+		require.Len(t, right["Pet"].Value.Properties, 2)
+
+		err := newLeft().Merge(Schemas{"Pet": NewArraySchema().NewRef()}, DeepMerge)
+		require.EqualError(t, err, `schema "Pet" has types "object" and "array"`)
+
+		err = newLeft().Merge(Schemas{"Pet": NewSchemaRef("#/components/schemas/Animal", nil)}, DeepMerge)
+		require.EqualError(t, err, `schema "Pet" references "" and "#/components/schemas/Animal"`)
+	})
+}
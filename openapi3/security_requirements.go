@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"context"
+	"fmt"
 )
 
 type SecurityRequirements []SecurityRequirement
@@ -47,5 +48,40 @@ func (security SecurityRequirement) Authenticate(provider string, scopes ...stri
 func (security *SecurityRequirement) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
 
+	if getValidationOptions(ctx).oauthScopesStrict {
+		if doc, ok := ctx.Value(documentKey{}).(*T); ok && doc.Components != nil {
+			for _, name := range sortedMapKeys(*security) {
+				if err := validateOAuthScopes(doc.Components.SecuritySchemes[name], name, (*security)[name]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOAuthScopes returns an error when one of scopes is not defined by the flows
+// of the OAuth2 security scheme name. Undefined schemes are reported by T.Validate.
+func validateOAuthScopes(schemeRef *SecuritySchemeRef, name string, scopes []string) error {
+	if schemeRef == nil || schemeRef.Value == nil || schemeRef.Value.Type != "oauth2" {
+		return nil
+	}
+	defined := make(map[string]struct{})
+	if flows := schemeRef.Value.Flows; flows != nil {
+		for _, flow := range []*OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+			if flow == nil {
+				continue
+			}
+			for scope := range flow.Scopes {
+				defined[scope] = struct{}{}
+			}
+		}
+	}
+	for _, scope := range scopes {
+		if _, ok := defined[scope]; !ok {
+			return fmt.Errorf("scope %q is not defined by security scheme %q", scope, name)
+		}
+	}
 	return nil
 }
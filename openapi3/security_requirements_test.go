@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -62,3 +63,50 @@ func TestSecurityRequirementEncoding(t *testing.T) {
 		require.Equal(t, test.json, string(b), "incorrect requirements encoding")
 	}
 }
+
+func TestWithStrictOAuthScopes(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+security:
+  - oauth2: [read:users]
+paths:
+  /users:
+    post:
+      security:
+        - oauth2: [write:users]
+          apiKey: [any]
+      responses:
+        '201':
+          description: created
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    oauth2:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            read:users: read users
+`[1:]
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background(), WithStrictOAuthScopes())
+	require.EqualError(t, err, `invalid paths: invalid path /users: invalid operation POST: invalid security: scope "write:users" is not defined by security scheme "oauth2"`)
+
+	doc.Components.SecuritySchemes["oauth2"].Value.Flows.ClientCredentials.Scopes["write:users"] = "write users"
+	err = doc.Validate(context.Background(), WithStrictOAuthScopes())
+	require.NoError(t, err)
+}
@@ -214,6 +214,15 @@ var securitySchemeExamples = []securitySchemeExample{
 		raw: []byte(`{
   "type": "openIdConnect",
   "openIdConnectUrl": ""
+}`),
+		valid: false,
+	},
+
+	{
+		title: "OIDC Type With Relative URL",
+		raw: []byte(`{
+  "type": "openIdConnect",
+  "openIdConnectUrl": "/.well-known/openid-configuration"
 }`),
 		valid: false,
 	},
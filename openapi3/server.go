@@ -34,13 +34,20 @@ func (servers Servers) BasePath() (string, error) {
 	return "/", nil
 }
 
+// MatchURL returns the first server matching parsedURL, its variables and the remaining path.
+// Relative server URLs (e.g. "/api/v1") match the path of parsedURL whatever its host.
 func (servers Servers) MatchURL(parsedURL *url.URL) (*Server, []string, string) {
 	rawURL := parsedURL.String()
 	if i := strings.IndexByte(rawURL, '?'); i >= 0 {
 		rawURL = rawURL[:i]
 	}
+	rawPath := parsedURL.EscapedPath()
 	for _, server := range servers {
-		pathParams, remaining, ok := server.MatchRawURL(rawURL)
+		input := rawURL
+		if strings.HasPrefix(server.URL, "/") {
+			input = rawPath
+		}
+		pathParams, remaining, ok := server.MatchRawURL(input)
 		if ok {
 			return server, pathParams, remaining
 		}
@@ -51,13 +58,37 @@ func (servers Servers) MatchURL(parsedURL *url.URL) (*Server, []string, string)
 // Server is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#server-object
 type Server struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	URL         string                     `json:"url" yaml:"url"` // Required
 	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
 	Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
 }
 
+func NewServer(url string) *Server {
+	return &Server{URL: url}
+}
+
+func (server *Server) WithDescription(value string) *Server {
+	server.Description = value
+	return server
+}
+
+// WithVariable declares the variable name used in the templated URL of the server.
+// Variables that do not match a placeholder of the URL, and placeholders without
+// a variable, are reported by Validate.
+func (server *Server) WithVariable(name, defaultValue string, enum []string, description string) *Server {
+	if server.Variables == nil {
+		server.Variables = make(map[string]*ServerVariable)
+	}
+	server.Variables[name] = &ServerVariable{
+		Enum:        enum,
+		Default:     defaultValue,
+		Description: description,
+	}
+	return server
+}
+
 // BasePath returns the base path extracted from the default values of variables, if any.
 // Assumes a valid struct (per Validate()).
 func (server *Server) BasePath() (string, error) {
@@ -222,7 +253,7 @@ func (server *Server) Validate(ctx context.Context, opts ...ValidationOption) (e
 // ServerVariable is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#server-variable-object
 type ServerVariable struct {
-	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
 
 	Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
 	Default     string   `json:"default,omitempty" yaml:"default,omitempty"`
@@ -3,6 +3,7 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -88,6 +89,32 @@ func TestServerValidation(t *testing.T) {
 	}
 }
 
+func TestServerBuilders(t *testing.T) {
+	server := NewServer("https://{env}.example.com/{basePath}").
+		WithDescription("Production").
+		WithVariable("env", "api", []string{"api", "staging"}, "Environment").
+		WithVariable("basePath", "v1", nil, "")
+	require.Equal(t, &Server{
+		URL:         "https://{env}.example.com/{basePath}",
+		Description: "Production",
+		Variables: map[string]*ServerVariable{
+			"env":      {Enum: []string{"api", "staging"}, Default: "api", Description: "Environment"},
+			"basePath": {Default: "v1"},
+		},
+	}, server)
+	require.NoError(t, server.Validate(context.Background()))
+
+	basePath, err := server.BasePath()
+	require.NoError(t, err)
+	require.Equal(t, "/v1", basePath)
+
+	err = NewServer("https://{env}.example.com").WithVariable("region", "eu", nil, "").Validate(context.Background())
+	require.EqualError(t, err, "server has undeclared variables")
+
+	err = NewServer("https://{env}.example.com/{basePath}").WithVariable("env", "api", nil, "").Validate(context.Background())
+	require.EqualError(t, err, "server has undeclared variables")
+}
+
 func testServerParamValues(t *testing.T, server *Server, input string, expected *serverMatch) func(*testing.T) {
 	return func(t *testing.T) {
 		args, remaining, ok := server.MatchRawURL(input)
@@ -201,3 +228,23 @@ func TestServersBasePath(t *testing.T) {
 		})
 	}
 }
+
+func TestServersMatchURLRelative(t *testing.T) {
+	servers := Servers{
+		&Server{URL: "https://example.com/v2"},
+		&Server{URL: "/api/v1"},
+	}
+	for _, input := range []string{"/api/v1/hello", "https://example.com/api/v1/hello"} {
+		u, err := url.Parse(input)
+		require.NoError(t, err)
+		server, _, remaining := servers.MatchURL(u)
+		require.Equal(t, servers[1], server)
+		require.Equal(t, "/hello", remaining)
+	}
+
+	u, err := url.Parse("https://example.com/v2/hello")
+	require.NoError(t, err)
+	server, _, remaining := servers.MatchURL(u)
+	require.Equal(t, servers[0], server)
+	require.Equal(t, "/hello", remaining)
+}
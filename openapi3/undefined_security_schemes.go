@@ -0,0 +1,65 @@
+package openapi3
+
+import "fmt"
+
+// UndefinedSecuritySchemeError is returned by T.Validate when a security requirement
+// names a security scheme that is not defined in components.securitySchemes.
+type UndefinedSecuritySchemeError struct {
+	// Path and Method locate the operation of the requirement, they are empty for the document's requirements.
+	Path   string
+	Method string
+	// Scheme is the name of the undefined security scheme.
+	Scheme string
+}
+
+var _ error = &UndefinedSecuritySchemeError{}
+
+func (err *UndefinedSecuritySchemeError) Error() string {
+	if err.Path == "" {
+		return fmt.Sprintf("security scheme %q of the document is not defined in components.securitySchemes", err.Scheme)
+	}
+	return fmt.Sprintf("security scheme %q of operation %s %s is not defined in components.securitySchemes", err.Scheme, err.Method, err.Path)
+}
+
+// validateSecuritySchemesDefined returns an UndefinedSecuritySchemeError
+// for each security scheme of the security requirements of doc that is not defined.
+func (doc *T) validateSecuritySchemesDefined() error {
+	var schemes SecuritySchemes
+	if doc.Components != nil {
+		schemes = doc.Components.SecuritySchemes
+	}
+
+	var me MultiError
+	check := func(path, method string, requirements SecurityRequirements) {
+		for _, requirement := range requirements {
+			for _, name := range sortedMapKeys(requirement) {
+				if _, ok := schemes[name]; !ok {
+					me = append(me, &UndefinedSecuritySchemeError{Path: path, Method: method, Scheme: name})
+				}
+			}
+		}
+	}
+
+	check("", "", doc.Security)
+	for _, path := range sortedMapKeys(doc.Paths) {
+		pathItem := doc.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedMapKeys(operations) {
+			if security := operations[method].Security; security != nil {
+				check(path, method, *security)
+			}
+		}
+	}
+
+	switch len(me) {
+	case 0:
+		return nil
+	case 1:
+		return me[0]
+	default:
+		return me
+	}
+}
@@ -0,0 +1,58 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndefinedSecuritySchemes(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      security:
+        - apiKey: []
+          basicAuth: []
+      responses:
+        '200':
+          description: pets
+    post:
+      security:
+        - bearerAuth: []
+      responses:
+        '201':
+          description: created
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`[1:]
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, ""+
+		`security scheme "basicAuth" of operation GET /pets is not defined in components.securitySchemes | `+
+		`security scheme "bearerAuth" of operation POST /pets is not defined in components.securitySchemes`)
+	me, ok := err.(MultiError)
+	require.True(t, ok)
+	require.Equal(t, &UndefinedSecuritySchemeError{Path: "/pets", Method: "GET", Scheme: "basicAuth"}, me[0])
+
+	delete(doc.Components.SecuritySchemes, "apiKey")
+	doc.Paths["/pets"].Get.Security = nil
+	doc.Paths["/pets"].Post.Security = nil
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `security scheme "apiKey" of the document is not defined in components.securitySchemes`)
+}
@@ -1,6 +1,7 @@
 package openapi3_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,7 +12,7 @@ import (
 func TestRegisterArrayUniqueItemsChecker(t *testing.T) {
 	var (
 		schema = openapi3.Schema{
-			Type:        "array",
+			Type:        openapi3.SchemaTypes{"array"},
 			UniqueItems: true,
 			Items:       openapi3.NewStringSchema().NewRef(),
 		}
@@ -34,3 +35,36 @@ func TestRegisterArrayUniqueItemsChecker(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, "duplicate items found")
 }
+
+func TestUniqueItemsOfObjects(t *testing.T) {
+	schema := openapi3.NewArraySchema().WithUniqueItems(true).WithItems(openapi3.NewObjectSchema())
+
+	err := schema.VisitJSON([]interface{}{
+		map[string]interface{}{"a": 1.0, "b": "x"},
+		map[string]interface{}{"a": 2.0, "b": "x"},
+	})
+	require.NoError(t, err)
+
+	err = schema.VisitJSON([]interface{}{
+		map[string]interface{}{"a": 1.0, "b": "x"},
+		map[string]interface{}{"b": "x", "a": 1.0},
+	})
+	require.ErrorContains(t, err, "duplicate items found")
+}
+
+func BenchmarkUniqueItems(b *testing.B) {
+	schema := openapi3.NewArraySchema().WithUniqueItems(true).WithItems(openapi3.NewObjectSchema())
+	for _, n := range []int{1000, 10000} {
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			items = append(items, map[string]interface{}{"id": float64(i), "name": fmt.Sprintf("item%d", i)})
+		}
+		b.Run(fmt.Sprintf("%d items", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := schema.VisitJSON(items); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
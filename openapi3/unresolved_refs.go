@@ -0,0 +1,47 @@
+package openapi3
+
+import (
+	"strconv"
+	"strings"
+)
+
+// UnresolvedRef is a schema reference of a document that was not resolved.
+type UnresolvedRef struct {
+	// JSONPointer locates the reference in the document, e.g. "/components/schemas/Pet/properties/owner".
+	JSONPointer string
+	// Ref is the value of the $ref.
+	Ref string
+}
+
+// UnresolvedRefsError is returned by T.Validate when schema references were not resolved,
+// e.g. when the document was not loaded with a Loader or a reference points to an unknown anchor.
+type UnresolvedRefsError struct {
+	Refs []UnresolvedRef
+}
+
+var _ error = &UnresolvedRefsError{}
+
+func (err *UnresolvedRefsError) Error() string {
+	refs := make([]string, 0, len(err.Refs))
+	for _, ref := range err.Refs {
+		refs = append(refs, strconv.Quote(ref.Ref)+" at "+ref.JSONPointer)
+	}
+	if len(refs) == 1 {
+		return "found unresolved ref: " + refs[0]
+	}
+	return "found unresolved refs: " + strings.Join(refs, ", ")
+}
+
+// unresolvedRefs returns the error listing the schema references of doc that were not resolved, if any.
+func (doc *T) unresolvedRefs() error {
+	var refs []UnresolvedRef
+	doc.walkSchemas(&schemaWalker{
+		unresolved: func(pointer, ref string) {
+			refs = append(refs, UnresolvedRef{JSONPointer: pointer, Ref: ref})
+		},
+	})
+	if len(refs) != 0 {
+		return &UnresolvedRefsError{Refs: refs}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUnresolvedRefs(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: MyAPI
+  version: 0.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+      - name: kind
+        in: query
+        schema:
+          $ref: './other.yaml#Kind'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+components:
+  schemas:
+    Pets:
+      type: array
+      items:
+        allOf:
+        - $ref: '#/components/schemas/Missing'
+        - not:
+            $ref: '#Animal'
+`)
+	// Unmarshal only so that references are left unresolved.
+	doc := &T{}
+	require.NoError(t, unmarshal(spec, doc))
+	doc.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema.Value = doc.Components.Schemas["Pets"].Value
+
+	err := doc.Validate(context.Background())
+	require.EqualError(t, err, `found unresolved refs: `+
+		`"#/components/schemas/Missing" at /components/schemas/Pets/items/allOf/0, `+
+		`"#Animal" at /components/schemas/Pets/items/allOf/1/not, `+
+		`"./other.yaml#Kind" at /paths/~1pets/get/parameters/0/schema`)
+
+	var unresolvedErr *UnresolvedRefsError
+	require.ErrorAs(t, err, &unresolvedErr)
+	require.Equal(t, []UnresolvedRef{
+		{JSONPointer: "/components/schemas/Pets/items/allOf/0", Ref: "#/components/schemas/Missing"},
+		{JSONPointer: "/components/schemas/Pets/items/allOf/1/not", Ref: "#Animal"},
+		{JSONPointer: "/paths/~1pets/get/parameters/0/schema", Ref: "./other.yaml#Kind"},
+	}, unresolvedErr.Refs)
+
+	// Visiting values does not panic on unresolved references either.
+	err = doc.Components.Schemas["Pets"].Value.VisitJSON([]interface{}{"cat"})
+	require.ErrorContains(t, err, `found unresolved ref: "#/components/schemas/Missing"`)
+}
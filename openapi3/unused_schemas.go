@@ -0,0 +1,54 @@
+package openapi3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// validateSchemasReferenced returns an error listing the schemas of the components
+// that cannot be reached by following "$ref"s from the paths, the webhooks
+// and the other components of the document.
+// Schemas only referenced by unreachable schemas, including themselves, are unreachable too.
+func (doc *T) validateSchemasReferenced() error {
+	if doc.Components == nil || len(doc.Components.Schemas) == 0 {
+		return nil
+	}
+
+	const prefix = "#/components/schemas/"
+	reached := make(map[string]struct{})
+	var pending []string
+	w := &schemaWalker{
+		reference: func(_, ref string) {
+			if !strings.HasPrefix(ref, prefix) {
+				return
+			}
+			// A reference to a subschema reaches its component too
+			name := jsonpointer.Unescape(strings.SplitN(strings.TrimPrefix(ref, prefix), "/", 2)[0])
+			if _, ok := reached[name]; !ok {
+				reached[name] = struct{}{}
+				pending = append(pending, name)
+			}
+		},
+	}
+	doc.walkSchemaUsers(w)
+	for len(pending) != 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		w.schemaRef("/components/schemas/"+escapeJSONPointerToken(name), doc.Components.Schemas[name])
+	}
+
+	var unused []string
+	for name := range doc.Components.Schemas {
+		if _, ok := reached[name]; !ok {
+			unused = append(unused, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+	sort.Strings(unused)
+	return fmt.Errorf("schemas are not referenced: %s", strings.Join(unused, ", "))
+}
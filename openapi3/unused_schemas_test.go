@@ -0,0 +1,102 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStrictUnusedSchemas(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Owner:
+      type: object
+    Orphan:
+      type: object
+      properties:
+        next:
+          $ref: '#/components/schemas/Orphan2'
+    Orphan2:
+      type: string
+    Node:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+    Unused:
+      type: string
+`[1:]
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(loader.Context)
+	require.NoError(t, err)
+
+	err = doc.Validate(loader.Context, WithStrictUnusedSchemas())
+	require.EqualError(t, err, `invalid components: schemas are not referenced: "Node", "Orphan", "Orphan2", "Unused"`)
+
+	for _, name := range []string{"Node", "Orphan", "Orphan2", "Unused"} {
+		delete(doc.Components.Schemas, name)
+	}
+	err = doc.Validate(context.Background(), WithStrictUnusedSchemas())
+	require.NoError(t, err)
+}
+
+func TestWithStrictUnusedSchemasCycles(t *testing.T) {
+	// Cyclic values cannot be encoded, they are walked once
+	node := NewObjectSchema()
+	node.WithPropertyRef("parent", &SchemaRef{Value: node})
+	tree := NewObjectSchema()
+	tree.WithPropertyRef("root", &SchemaRef{Ref: "#/components/schemas/Node", Value: node})
+
+	doc := &T{
+		OpenAPI: "3.0.3",
+		Info:    &Info{Title: "MyAPI", Version: "0.1"},
+		Paths:   Paths{},
+		Components: &Components{
+			Schemas: Schemas{
+				"Node": node.NewRef(),
+				"Tree": tree.NewRef(),
+			},
+		},
+	}
+	err := doc.Validate(context.Background(), WithStrictUnusedSchemas())
+	require.EqualError(t, err, `invalid components: schemas are not referenced: "Node", "Tree"`)
+
+	doc.Components.RequestBodies = RequestBodies{
+		"Tree": {Value: NewRequestBody().WithJSONSchemaRef(&SchemaRef{Ref: "#/components/schemas/Tree", Value: tree})},
+	}
+	err = doc.Validate(context.Background(), WithStrictUnusedSchemas())
+	require.NoError(t, err)
+}
@@ -1,6 +1,10 @@
 package openapi3
 
-import "context"
+import (
+	"context"
+	"net/http"
+	"strings"
+)
 
 // ValidationOption allows the modification of how the OpenAPI document is validated.
 type ValidationOption func(options *ValidationOptions)
@@ -11,8 +15,22 @@ type ValidationOptions struct {
 	examplesValidationDisabled                       bool
 	schemaDefaultsValidationDisabled                 bool
 	schemaFormatValidationEnabled                    bool
+	schemaFormatAssertionsDisabled                   bool
 	schemaPatternValidationDisabled                  bool
 	extraSiblingFieldsAllowed                        map[string]struct{}
+	refResolver                                      func(ref string) (interface{}, error)
+	semanticVersioningEnabled                        bool
+	futureVersionsAllowed                            bool
+	descriptionsRequired                             bool
+	nonStandardPathsAllowed                          bool
+	unusedSchemasDisallowed                          bool
+	componentNamesStrict                             bool
+	informationalResponsesDisallowed                 bool
+	oauthScopesStrict                                bool
+	successResponseRequired                          bool
+	pathPrefix                                       string
+	externalDocsValidationEnabled                    bool
+	httpClient                                       *http.Client
 }
 
 type validationOptionsKey struct{}
@@ -29,6 +47,116 @@ func AllowExtraSiblingFields(fields ...string) ValidationOption {
 	}
 }
 
+// WithRefResolver makes Validate resolve $refs that were not loaded with the given function
+// instead of returning an error, enabling validation of documents that intentionally omit some referenced components.
+// The resolved value is validated when it is of the expected type (e.g. *Schema for a SchemaRef),
+// and a nil value marks the reference as resolved without further validation.
+func WithRefResolver(resolver func(ref string) (interface{}, error)) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.refResolver = resolver
+	}
+}
+
+// WithSemanticVersioning makes Validate return an error when info.version is not a semantic version (e.g. 1.2.3).
+func WithSemanticVersioning() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.semanticVersioningEnabled = true
+	}
+}
+
+// WithAllowFutureVersions makes Validate not return an error for openapi versions
+// with a minor version other than the published ones (3.0 and 3.1).
+func WithAllowFutureVersions() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.futureVersionsAllowed = true
+	}
+}
+
+// WithRequireDescriptions makes Validate return an error for each path item and operation
+// with an empty summary or description.
+func WithRequireDescriptions() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.descriptionsRequired = true
+	}
+}
+
+// WithAllowNonStandardPaths makes Validate not return an error for path templates with
+// placeholders other than {name}, such as the {id:[0-9]+} or {path*} syntaxes of some routers.
+func WithAllowNonStandardPaths() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.nonStandardPathsAllowed = true
+	}
+}
+
+// WithStrictUnusedSchemas makes Validate return an error when some of the schemas
+// of the components are not referenced anywhere in the document.
+func WithStrictUnusedSchemas() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.unusedSchemasDisallowed = true
+	}
+}
+
+// WithStrictComponentNames makes Validate return an error when components of different types
+// (e.g. a schema and a response) share a name, which confuses some code generators.
+func WithStrictComponentNames() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.componentNamesStrict = true
+	}
+}
+
+// WithStrict1xxHandling makes Validate return an error when an operation documents
+// an informational (1xx) response: such responses are not final and cannot be meaningfully described.
+func WithStrict1xxHandling() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.informationalResponsesDisallowed = true
+	}
+}
+
+// WithStrictOAuthScopes makes Validate return an error when a security requirement
+// uses a scope that is not defined by the flows of its OAuth2 security scheme.
+// It is disabled by default as many documents intentionally use scopes they do not list.
+func WithStrictOAuthScopes() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.oauthScopesStrict = true
+	}
+}
+
+// WithRequireSuccessResponse makes ValidateWithResult warn about operations
+// whose responses have no successful (2xx) code and no default.
+func WithRequireSuccessResponse() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.successResponseRequired = true
+	}
+}
+
+// WithSetPath makes the JSON Pointers reported by validation start with path,
+// e.g. "/components/schemas/Pet" when validating a document extracted from a larger one:
+// errors of components are located by their JSON Pointer instead of their name
+// and the JSON Pointers of schema errors of defaults and examples are prefixed,
+// as well as those of ValidateWithResult warnings and WithRequireDescriptions errors.
+func WithSetPath(path string) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.pathPrefix = strings.TrimSuffix(path, "/")
+	}
+}
+
+// WithValidateExternalDocs makes Validate send a HEAD request to the URL of each external documentation
+// and return an error when it does not respond with a 2xx status.
+// Requests are canceled with the context given to Validate. It is disabled by default.
+func WithValidateExternalDocs(enabled bool) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.externalDocsValidationEnabled = enabled
+	}
+}
+
+// WithValidationHTTPClient sets the http.Client used by the validations sending requests,
+// such as WithValidateExternalDocs. Defaults to http.DefaultClient.
+func WithValidationHTTPClient(cl *http.Client) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.httpClient = cl
+	}
+}
+
 // EnableSchemaFormatValidation makes Validate not return an error when validating documents that mention schema formats that are not defined by the OpenAPIv3 specification.
 // By default, schema format validation is disabled.
 func EnableSchemaFormatValidation() ValidationOption {
@@ -37,6 +165,16 @@ func EnableSchemaFormatValidation() ValidationOption {
 	}
 }
 
+// WithDisabledSchemaFormatAssertions makes Validate treat formats as annotations:
+// unknown formats are accepted and examples and defaults are not checked against the formats of their schemas.
+// Other schema keywords are still validated.
+// It takes precedence over EnableSchemaFormatValidation, whatever the order they are given in.
+func WithDisabledSchemaFormatAssertions() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.schemaFormatAssertionsDisabled = true
+	}
+}
+
 // DisableSchemaFormatValidation does the opposite of EnableSchemaFormatValidation.
 // By default, schema format validation is disabled.
 func DisableSchemaFormatValidation() ValidationOption {
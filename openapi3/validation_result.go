@@ -0,0 +1,149 @@
+package openapi3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationWarning is an issue of a document that does not violate the OpenAPI spec
+// but is likely to be a mistake or to degrade generated documentation and code.
+type ValidationWarning struct {
+	// JSONPointer locates the issue in the document, e.g. "/paths/~1pets/get".
+	JSONPointer string
+	// Message describes the issue.
+	Message string
+}
+
+// ValidationResult is returned by ValidateWithResult.
+type ValidationResult struct {
+	Warnings []ValidationWarning
+}
+
+// ValidateWithResult validates the document like Validate, then reports non-fatal issues as warnings:
+// an unknown $schema dialect, operations without tags, declared tags that no operation uses,
+// component schemas without description, informational (1xx) responses
+// and allOf, anyOf or oneOf entries resolving to the same schema as a previous entry.
+// With WithRequireSuccessResponse, operations without a 2xx or default response are reported too.
+// Warnings are only collected for valid documents.
+func (doc *T) ValidateWithResult(ctx context.Context, opts ...ValidationOption) (*ValidationResult, error) {
+	if err := doc.Validate(ctx, opts...); err != nil {
+		return nil, err
+	}
+
+	options := getValidationOptions(WithValidationOptions(ctx, opts...))
+	prefix := options.pathPrefix
+	result := &ValidationResult{}
+	warn := func(pointer, message string) {
+		result.Warnings = append(result.Warnings, ValidationWarning{JSONPointer: prefix + pointer, Message: message})
+	}
+
+	if doc.Schema != "" && !isKnownSchemaDialect(doc.Schema) {
+		warn("/$schema", fmt.Sprintf("unknown $schema %q: the document is validated as per the OpenAPI dialect", doc.Schema))
+	}
+
+	usedTags := make(map[string]struct{})
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		pathItem := doc.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := operations[method]
+			location := "/paths/" + escapeJSONPointerToken(path) + "/" + strings.ToLower(method)
+			if len(operation.Tags) == 0 {
+				warn(location, "operation has no tags")
+			}
+			for _, tag := range operation.Tags {
+				usedTags[tag] = struct{}{}
+			}
+
+			codes := make([]string, 0, len(operation.Responses))
+			for code := range operation.Responses {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			successful := false
+			for _, code := range codes {
+				if len(code) == 3 && code[0] == '1' {
+					warn(location+"/responses/"+code, "informational responses are not final")
+				}
+				if code == "default" || (len(code) == 3 && code[0] == '2') {
+					successful = true
+				}
+			}
+			if options.successResponseRequired && !successful {
+				warn(location+"/responses", fmt.Sprintf("operation %s %s has no successful (2xx) or default response", method, path))
+			}
+		}
+	}
+
+	for i, tag := range doc.Tags {
+		if tag == nil {
+			continue
+		}
+		if _, ok := usedTags[tag.Name]; !ok {
+			warn("/tags/"+strconv.Itoa(i), fmt.Sprintf("tag %q is not used by any operation", tag.Name))
+		}
+	}
+
+	if components := doc.Components; components != nil {
+		names := make([]string, 0, len(components.Schemas))
+		for name := range components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			schemaRef := components.Schemas[name]
+			if schemaRef == nil || schemaRef.Ref != "" || schemaRef.Value == nil {
+				continue
+			}
+			if schemaRef.Value.Description == "" {
+				warn("/components/schemas/"+escapeJSONPointerToken(name), "schema has no description")
+			}
+		}
+	}
+
+	doc.walkSchemas(&schemaWalker{
+		visit: func(pointer string, schema *Schema) {
+			for _, of := range []struct {
+				keyword string
+				refs    SchemaRefs
+			}{{"allOf", schema.AllOf}, {"anyOf", schema.AnyOf}, {"oneOf", schema.OneOf}} {
+				refs := make(map[string]int, len(of.refs))
+				values := make(map[*Schema]int, len(of.refs))
+				for i, schemaRef := range of.refs {
+					if schemaRef == nil {
+						continue
+					}
+					location := pointer + "/" + of.keyword + "/" + strconv.Itoa(i)
+					if j, ok := refs[schemaRef.Ref]; ok && schemaRef.Ref != "" {
+						warn(location, fmt.Sprintf("%s entry %q duplicates entry %d", of.keyword, schemaRef.Ref, j))
+						continue
+					}
+					if j, ok := values[schemaRef.Value]; ok && schemaRef.Value != nil {
+						warn(location, fmt.Sprintf("%s entry duplicates entry %d", of.keyword, j))
+						continue
+					}
+					refs[schemaRef.Ref] = i
+					values[schemaRef.Value] = i
+				}
+			}
+		},
+	})
+
+	return result, nil
+}
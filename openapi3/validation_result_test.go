@@ -0,0 +1,177 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWithResult(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+- name: pets
+- name: store
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      responses:
+        '100':
+          description: Continue
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      description: A pet
+    Error:
+      type: object
+`[1:]
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	result, err := doc.ValidateWithResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []ValidationWarning{
+		{JSONPointer: "/paths/~1pets/post", Message: "operation has no tags"},
+		{JSONPointer: "/paths/~1pets/post/responses/100", Message: "informational responses are not final"},
+		{JSONPointer: "/tags/1", Message: `tag "store" is not used by any operation`},
+		{JSONPointer: "/components/schemas/Error", Message: "schema has no description"},
+	}, result.Warnings)
+
+	// Errors short-circuit warnings
+	result, err = doc.ValidateWithResult(context.Background(), WithStrict1xxHandling())
+	require.EqualError(t, err, `invalid paths: invalid path /pets: invalid operation POST: response "100" is informational: 1xx responses are not final`)
+	require.Nil(t, result)
+}
+
+func TestValidateWithResultSetPath(t *testing.T) {
+	doc := NewT().WithInfo(&Info{Title: "Pets", Version: "1.0.0"})
+	require.NoError(t, doc.AddOperation("/pets", "GET", NewOperation().WithResponse(200, NewResponse().WithDescription("OK"))))
+
+	result, err := doc.ValidateWithResult(context.Background(), WithSetPath("/x-apis/pets/"))
+	require.NoError(t, err)
+	require.Equal(t, []ValidationWarning{
+		{JSONPointer: "/x-apis/pets/paths/~1pets/get", Message: "operation has no tags"},
+	}, result.Warnings)
+}
+
+func TestValidateWithResultDuplicateSchemaCompositions(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Foo:
+      type: object
+      description: Foo
+    Pet:
+      description: A pet
+      allOf:
+      - $ref: '#/components/schemas/Foo'
+      - $ref: '#/components/schemas/Foo'
+      oneOf:
+      - $ref: '#/components/schemas/Foo'
+      - type: object
+`[1:]
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	foo := doc.Components.Schemas["Foo"].Value
+	doc.Components.Schemas["Pet"].Value.AnyOf = SchemaRefs{{Value: foo}, {Value: foo}}
+
+	result, err := doc.ValidateWithResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []ValidationWarning{
+		{JSONPointer: "/components/schemas/Pet/allOf/1", Message: `allOf entry "#/components/schemas/Foo" duplicates entry 0`},
+		{JSONPointer: "/components/schemas/Pet/anyOf/1", Message: "anyOf entry duplicates entry 0"},
+	}, result.Warnings)
+}
+
+func TestValidateWithResultRequireSuccessResponse(t *testing.T) {
+	doc := NewT().WithInfo(&Info{Title: "Pets", Version: "1.0.0"})
+	require.NoError(t, doc.AddOperation("/pets", "GET", &Operation{
+		Tags: []string{"pets"},
+		Responses: Responses{
+			"404": {Value: NewResponse().WithDescription("Not found")},
+			"5XX": {Value: NewResponse().WithDescription("Error")},
+		},
+	}))
+	require.NoError(t, doc.AddOperation("/pets", "POST", &Operation{
+		Tags:      []string{"pets"},
+		Responses: Responses{"2XX": {Value: NewResponse().WithDescription("Created")}},
+	}))
+	require.NoError(t, doc.AddOperation("/pets", "DELETE", NewOperation().WithTag("pets").
+		WithDefaultResponse(NewResponse().WithDescription("Done"))))
+
+	result, err := doc.ValidateWithResult(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, result.Warnings)
+
+	result, err = doc.ValidateWithResult(context.Background(), WithRequireSuccessResponse())
+	require.NoError(t, err)
+	require.Equal(t, []ValidationWarning{
+		{JSONPointer: "/paths/~1pets/get/responses", Message: "operation GET /pets has no successful (2xx) or default response"},
+	}, result.Warnings)
+}
+
+func TestValidateWithResultSchemaDialect(t *testing.T) {
+	spec := func(version, schema string) []byte {
+		return []byte(`openapi: ` + version + `
+$schema: ` + schema + `
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+`)
+	}
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec("3.1.0", SchemaOAS31MetaBase))
+	require.NoError(t, err)
+	require.Equal(t, SchemaOAS31MetaBase, doc.Schema)
+	require.Empty(t, doc.Extensions)
+
+	result, err := doc.ValidateWithResult(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, result.Warnings)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"$schema":"https://spec.openapis.org/oas/3.1/meta/base"`)
+
+	doc, err = loader.LoadFromData(spec("3.1.0", "https://example.com/dialects/custom"))
+	require.NoError(t, err)
+	result, err = doc.ValidateWithResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []ValidationWarning{
+		{JSONPointer: "/$schema", Message: `unknown $schema "https://example.com/dialects/custom": the document is validated as per the OpenAPI dialect`},
+	}, result.Warnings)
+
+	doc, err = loader.LoadFromData(spec("3.0.3", SchemaOAS31DialectBase))
+	require.NoError(t, err)
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `$schema "https://spec.openapis.org/oas/3.1/dialect/base" is an OpenAPI 3.1 dialect, not supported by openapi "3.0.3"`)
+}
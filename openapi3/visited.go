@@ -2,14 +2,17 @@ package openapi3
 
 func newVisited() visitedComponent {
 	return visitedComponent{
-		header: make(map[*Header]struct{}),
-		schema: make(map[*Schema]struct{}),
+		header:     make(map[*Header]struct{}),
+		schema:     make(map[*Schema]struct{}),
+		remoteRefs: make(map[string]string),
 	}
 }
 
 type visitedComponent struct {
 	header map[*Header]struct{}
 	schema map[*Schema]struct{}
+	// remoteRefs maps the names of the components added by InlineRemoteRefs to their remote reference.
+	remoteRefs map[string]string
 }
 
 // resetVisited clears visitedComponent map
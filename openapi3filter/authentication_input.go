@@ -2,6 +2,8 @@ package openapi3filter
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -11,6 +13,21 @@ type AuthenticationInput struct {
 	SecuritySchemeName     string
 	SecurityScheme         *openapi3.SecurityScheme
 	Scopes                 []string
+
+	// Token is the credential sent with the request for the security scheme:
+	// the value of the parameter of apiKey schemes, and the Authorization header
+	// without its scheme prefix (e.g. "Bearer ") for http, oauth2 and openIdConnect schemes.
+	// It is empty when the request does not carry any.
+	Token string
+}
+
+// RequiredScopes returns the scopes that the security requirement being evaluated
+// lists for the security scheme. It is empty for schemes other than oauth2 and openIdConnect.
+func (input *AuthenticationInput) RequiredScopes() []string {
+	if scheme := input.SecurityScheme; scheme == nil || (scheme.Type != "oauth2" && scheme.Type != "openIdConnect") {
+		return nil
+	}
+	return input.Scopes
 }
 
 func (input *AuthenticationInput) NewError(err error) error {
@@ -27,3 +44,39 @@ func (input *AuthenticationInput) NewError(err error) error {
 		Err:    err,
 	}
 }
+
+// securityToken extracts the credential of the security scheme from the request.
+func securityToken(req *http.Request, securityScheme *openapi3.SecurityScheme) string {
+	if req == nil {
+		return ""
+	}
+	switch securityScheme.Type {
+	case "apiKey":
+		switch securityScheme.In {
+		case openapi3.ParameterInQuery:
+			return req.URL.Query().Get(securityScheme.Name)
+		case openapi3.ParameterInHeader:
+			return req.Header.Get(securityScheme.Name)
+		case openapi3.ParameterInCookie:
+			if cookie, err := req.Cookie(securityScheme.Name); err == nil {
+				return cookie.Value
+			}
+		}
+	case "http":
+		return authorizationCredentials(req, securityScheme.Scheme)
+	case "oauth2", "openIdConnect":
+		return authorizationCredentials(req, "bearer")
+	}
+	return ""
+}
+
+// authorizationCredentials returns the Authorization header of the request
+// stripped of its scheme, which is matched case-insensitively.
+func authorizationCredentials(req *http.Request, scheme string) string {
+	value := req.Header.Get("Authorization")
+	prefix := scheme + " "
+	if len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+		return strings.TrimSpace(value[len(prefix):])
+	}
+	return ""
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
 )
 
 var _ error = &RequestError{}
@@ -16,6 +17,12 @@ type RequestError struct {
 	RequestBody *openapi3.RequestBody
 	Reason      string
 	Err         error
+
+	// RouteMatched is set by ValidateRequest, which only validates requests that matched a route.
+	// Routing failures are reported by routers with a *routers.RouteError instead.
+	RouteMatched bool
+	// MatchedRoute is the route the request was validated against, set by ValidateRequest.
+	MatchedRoute *routers.Route
 }
 
 var _ interface{ Unwrap() error } = RequestError{}
@@ -2,15 +2,87 @@ package openapi3filter
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 )
 
+// parseMediaType returns the lowercased media type of contentType, without its parameters.
 func parseMediaType(contentType string) string {
-	i := strings.IndexByte(contentType, ';')
-	if i < 0 {
-		return contentType
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// acceptRange is a media range of an Accept header with its quality value, see RFC 7231 section 5.3.2.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+type acceptRanges []acceptRange
+
+// parseAcceptHeader returns the media ranges of the Accept header value accept.
+// Media ranges with an invalid quality value are ignored.
+func parseAcceptHeader(accept string) acceptRanges {
+	var ranges acceptRanges
+	for _, element := range strings.Split(accept, ",") {
+		params := strings.Split(element, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaType == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(param, "=")
+			if strings.ToLower(strings.TrimSpace(name)) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				quality = -1
+			} else {
+				quality = q
+			}
+			break
+		}
+		if quality < 0 {
+			continue
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// quality returns the quality value of mediaType given by its most specific matching media range,
+// 0 when none matches. mediaType may itself be a range, such as the "application/*" content of a response.
+func (ranges acceptRanges) quality(mediaType string) float64 {
+	quality, specificity := 0.0, -1
+	for _, r := range ranges {
+		if s := mediaRangeMatch(r.mediaType, mediaType); s > specificity {
+			quality, specificity = r.quality, s
+		}
+	}
+	return quality
+}
+
+// mediaRangeMatch returns how specifically the media range matches mediaType:
+// -1 when they do not match, 0 for */*, 1 for type/* and 2 for type/subtype.
+func mediaRangeMatch(mediaRange, mediaType string) int {
+	rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	switch {
+	case rangeType == "*":
+		return 0
+	case typ != "*" && rangeType != typ:
+		return -1
+	case rangeSubtype == "*":
+		return 1
+	case subtype != "*" && rangeSubtype != subtype:
+		return -1
+	default:
+		return 2
 	}
-	return contentType[:i]
 }
 
 func isNilValue(value interface{}) bool {
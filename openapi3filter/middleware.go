@@ -2,11 +2,14 @@ package openapi3filter
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/routers"
 )
 
@@ -114,6 +117,81 @@ func ValidationOptions(options Options) ValidatorOption {
 	}
 }
 
+// ErrorFormatter returns the status code and the body of the response
+// written on validation errors. A zero status code keeps the one chosen by
+// the Validator.
+type ErrorFormatter func(err error) (statusCode int, body interface{})
+
+// WithCustomErrorFormatter provides a callback that formats the validation
+// error responses, which bodies are then encoded as JSON. It replaces any
+// callback set with OnErr.
+func WithCustomErrorFormatter(f ErrorFormatter) ValidatorOption {
+	return func(v *Validator) {
+		v.errFunc = func(w http.ResponseWriter, status int, _ ErrCode, err error) {
+			statusCode, body := f(err)
+			if statusCode == 0 {
+				statusCode = status
+			}
+			contentType := "application/json"
+			if _, ok := body.(*ProblemDetails); ok {
+				contentType = "application/problem+json"
+			}
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(statusCode)
+			if err := json.NewEncoder(w).Encode(body); err != nil {
+				v.logFunc("failed to write error response", err)
+			}
+		}
+	}
+}
+
+// ProblemDetails is an RFC 7807 problem details object.
+type ProblemDetails struct {
+	Type   string `json:"type" yaml:"type"`
+	Title  string `json:"title" yaml:"title"`
+	Status int    `json:"status,omitempty" yaml:"status,omitempty"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	// Errors lists the schema violations of the request or response, if any.
+	Errors []openapi3.SchemaViolation `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// RFC7807ErrorFormatter is an ErrorFormatter producing RFC 7807 problem
+// details, with status codes chosen like ValidationErrorEncoder does.
+func RFC7807ErrorFormatter(err error) (int, interface{}) {
+	problem := &ProblemDetails{Type: "about:blank"}
+	if e, ok := ConvertErrors(err).(*ValidationError); ok {
+		problem.Status = e.Status
+		problem.Title = e.Title
+		problem.Detail = e.Detail
+	} else {
+		problem.Status = http.StatusBadRequest
+		if _, ok := err.(*ResponseError); ok {
+			problem.Status = http.StatusInternalServerError
+		}
+		problem.Title = http.StatusText(problem.Status)
+		problem.Detail = err.Error()
+	}
+	problem.Errors = schemaViolations(err)
+	return problem.Status, problem
+}
+
+func schemaViolations(err error) []openapi3.SchemaViolation {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *openapi3.SchemaError:
+		return []openapi3.SchemaViolation{e.AsSchemaViolation()}
+	case openapi3.MultiError:
+		var violations []openapi3.SchemaViolation
+		for _, err := range e {
+			violations = append(violations, schemaViolations(err)...)
+		}
+		return violations
+	default:
+		return schemaViolations(errors.Unwrap(err))
+	}
+}
+
 // Middleware returns an http.Handler which wraps the given handler with
 // request and response validation.
 func (v *Validator) Middleware(h http.Handler) http.Handler {
@@ -348,6 +348,58 @@ func TestValidator(t *testing.T) {
 			body:       `{"id": "42", "contents": {"name": "foo", "expected": 9, "actual": 10}, "extra": true}`,
 		},
 		strict: false,
+	}, {
+		name:    "method not allowed; RFC 7807 problem details",
+		handler: validatorTestHandler{}.withDefaults(),
+		options: []openapi3filter.ValidatorOption{openapi3filter.WithCustomErrorFormatter(openapi3filter.RFC7807ErrorFormatter)},
+		request: testRequest{
+			method: "GET",
+			path:   "/test?version=1",
+		},
+		response: testResponse{
+			405, `{"type":"about:blank","title":"method not allowed","status":405}` + "\n",
+		},
+		strict: true,
+	}, {
+		name:    "invalid request; RFC 7807 problem details",
+		handler: validatorTestHandler{}.withDefaults(),
+		options: []openapi3filter.ValidatorOption{openapi3filter.WithCustomErrorFormatter(openapi3filter.RFC7807ErrorFormatter)},
+		request: testRequest{
+			method: "GET",
+			path:   "/test/42",
+		},
+		response: testResponse{
+			400, `{"type":"about:blank","title":"parameter \"version\" in query is required","status":400}` + "\n",
+		},
+		strict: true,
+	}, {
+		name:    "invalid request body; RFC 7807 problem details with schema violations",
+		handler: validatorTestHandler{}.withDefaults(),
+		options: []openapi3filter.ValidatorOption{openapi3filter.WithCustomErrorFormatter(openapi3filter.RFC7807ErrorFormatter)},
+		request: testRequest{
+			method:      "POST",
+			path:        "/test?version=1",
+			body:        `{"name": "foo", "expected": "nine", "actual": 10}`,
+			contentType: "application/json",
+		},
+		response: testResponse{
+			422, `{"type":"about:blank","title":"value must be a number","status":422,"errors":[{"instancePath":"/expected","keyword":"type","message":"value must be a number","schemaPath":"#/properties/expected/type"}]}` + "\n",
+		},
+		strict: true,
+	}, {
+		name:    "invalid response; custom error formatter keeping the status code",
+		handler: validatorTestHandler{getBody: `{"id": "42", "contents": {"name": "foo", "expected": 9, "actual": 10}, "extra": true}`}.withDefaults(),
+		options: []openapi3filter.ValidatorOption{openapi3filter.WithCustomErrorFormatter(func(err error) (int, interface{}) {
+			return 0, map[string]string{"error": "invalid"}
+		})},
+		request: testRequest{
+			method: "GET",
+			path:   "/test/42?version=1",
+		},
+		response: testResponse{
+			500, `{"error":"invalid"}` + "\n",
+		},
+		strict: true,
 	}}
 	for i, test := range tests {
 		t.Logf("test#%d: %s", i, test.name)
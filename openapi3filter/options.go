@@ -1,12 +1,37 @@
 package openapi3filter
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
 
 // Options used by ValidateRequest and ValidateResponse
 type Options struct {
 	// Set ExcludeRequestBody so ValidateRequest skips request body validation
 	ExcludeRequestBody bool
 
+	// Set SkipEmptyRequestBody so ValidateRequest neither reads nor validates request bodies
+	// with a ContentLength of 0, which leaves them untouched for the handler.
+	// Required request bodies are still reported missing.
+	// Note that servers already set the body of such requests to http.NoBody, which is never read,
+	// and that a ContentLength of 0 means an unknown length for client requests.
+	SkipEmptyRequestBody bool
+
+	// Set AllowMissingRequiredRequestBody so ValidateRequest accepts requests without
+	// the body their operation requires, e.g. for legacy clients the handler supplies defaults for.
+	// The error is then passed to WarningFunc, if set.
+	AllowMissingRequiredRequestBody bool
+
+	// Set MaximumBodySize to a positive number of bytes so ValidateRequest and ValidateResponse
+	// fail with ErrBodyTooLarge instead of reading larger bodies
+	MaximumBodySize int64
+
+	// Set DecompressRequestBody so ValidateRequest decompresses request bodies
+	// whose Content-Encoding is gzip or deflate before validating them.
+	// MaximumBodySize then also limits the size of the decompressed body.
+	DecompressRequestBody bool
+
 	// Set ExcludeResponseBody so ValidateResponse skips response body validation
 	ExcludeResponseBody bool
 
@@ -20,8 +45,15 @@ type Options struct {
 	// status not defined in OpenAPI spec
 	IncludeResponseStatus bool
 
+	// Set Strict1xxHandling so ValidateResponse validates the headers of informational (1xx) responses
+	// and fails on those their operation does not document, instead of skipping them.
+	Strict1xxHandling bool
+
 	MultiError bool
 
+	// WarningFunc, if set, is called with the validation errors the options above demote to warnings.
+	WarningFunc func(ctx context.Context, err error)
+
 	// A document with security schemes defined will not pass validation
 	// unless an AuthenticationFunc is defined.
 	// See NoopAuthenticationFunc
@@ -32,6 +64,11 @@ type Options struct {
 	SkipSettingDefaults bool
 
 	customSchemaErrorFunc CustomSchemaErrorFunc
+
+	acceptHeaderValidated bool
+
+	pathParamDecoder  PathParamDecoder
+	pathParamDecoders map[string]PathParamDecoder
 }
 
 // CustomSchemaErrorFunc allows for custom the schema error message.
@@ -42,3 +79,40 @@ type CustomSchemaErrorFunc func(err *openapi3.SchemaError) string
 func (o *Options) WithCustomSchemaErrorFunc(f CustomSchemaErrorFunc) {
 	o.customSchemaErrorFunc = f
 }
+
+// WithValidateAcceptHeader makes ValidateRequest check that one of the media types accepted by the request
+// is produced by the responses of its operation. As content negotiation is up to the handler,
+// requests are not rejected: an ErrNotAcceptable RequestError is passed to WarningFunc instead.
+func (o *Options) WithValidateAcceptHeader(enabled bool) {
+	o.acceptHeaderValidated = enabled
+}
+
+// PathParamDecoder decodes the raw value of a path parameter into the value validated against its schema.
+type PathParamDecoder func(schema *openapi3.Schema, value string) (interface{}, error)
+
+// WithPathParamDecoder makes ValidateRequest decode the values of the path parameters named params,
+// or of all path parameters when no names are given, with fn instead of their style.
+// Decoders of named parameters take precedence over the decoder of all path parameters.
+func (o *Options) WithPathParamDecoder(fn PathParamDecoder, params ...string) {
+	if len(params) == 0 {
+		o.pathParamDecoder = fn
+		return
+	}
+	if o.pathParamDecoders == nil {
+		o.pathParamDecoders = make(map[string]PathParamDecoder, len(params))
+	}
+	for _, param := range params {
+		o.pathParamDecoders[param] = fn
+	}
+}
+
+// getPathParamDecoder returns the decoder of the parameter set by WithPathParamDecoder, if any.
+func (o *Options) getPathParamDecoder(parameter *openapi3.Parameter) PathParamDecoder {
+	if parameter.In != openapi3.ParameterInPath {
+		return nil
+	}
+	if fn, ok := o.pathParamDecoders[parameter.Name]; ok {
+		return fn
+	}
+	return o.pathParamDecoder
+}
@@ -3,6 +3,8 @@ package openapi3filter
 import (
 	"archive/zip"
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
@@ -194,7 +196,7 @@ func defaultContentParameterDecoder(param *openapi3.Parameter, values []string)
 
 	unmarshal := func(encoded string, paramSchema *openapi3.SchemaRef) (decoded interface{}, err error) {
 		if err = json.Unmarshal([]byte(encoded), &decoded); err != nil {
-			if paramSchema != nil && paramSchema.Value.Type != "object" {
+			if paramSchema != nil && !paramSchema.Value.Type.Is("object") {
 				decoded, err = encoded, nil
 			}
 		}
@@ -315,14 +317,14 @@ func decodeValue(dec valueDecoder, param string, sm *openapi3.SerializationMetho
 		return nil, found, errors.New("not implemented: decoding 'not'")
 	}
 
-	if schema.Value.Type != "" {
+	if schemaType := schema.Value.Type; len(schemaType) != 0 {
 		var decodeFn func(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) (interface{}, bool, error)
-		switch schema.Value.Type {
-		case "array":
+		switch {
+		case schemaType.Includes("array"):
 			decodeFn = func(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) (interface{}, bool, error) {
 				return dec.DecodeArray(param, sm, schema)
 			}
-		case "object":
+		case schemaType.Includes("object"):
 			decodeFn = func(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) (interface{}, bool, error) {
 				return dec.DecodeObject(param, sm, schema)
 			}
@@ -333,6 +335,9 @@ func decodeValue(dec valueDecoder, param string, sm *openapi3.SerializationMetho
 	}
 	switch vDecoder := dec.(type) {
 	case *pathParamDecoder:
+		if schema.Value.Pattern != "" {
+			return dec.DecodePrimitive(param, sm, schema)
+		}
 		_, found = vDecoder.pathParams[param]
 	case *urlValuesDecoder:
 		if schema.Value.Pattern != "" {
@@ -505,7 +510,7 @@ func (d *urlValuesDecoder) DecodePrimitive(param string, sm *openapi3.Serializat
 		return nil, ok, nil
 	}
 
-	if schema.Value.Type == "" && schema.Value.Pattern != "" {
+	if len(schema.Value.Type) == 0 && schema.Value.Pattern != "" {
 		return values[0], ok, nil
 	}
 	val, err := parsePrimitive(values[0], schema)
@@ -889,41 +894,58 @@ func parseArray(raw []string, schemaRef *openapi3.SchemaRef) ([]interface{}, err
 
 // parsePrimitive returns a value that is created by parsing a source string to a primitive type
 // that is specified by a schema. The function returns nil when the source string is empty.
+// The types of schemas with several of them are tried in order.
 // The function panics when a schema has a non-primitive type.
-func parsePrimitive(raw string, schema *openapi3.SchemaRef) (interface{}, error) {
+func parsePrimitive(raw string, schema *openapi3.SchemaRef) (v interface{}, err error) {
 	if raw == "" {
 		return nil, nil
 	}
-	switch schema.Value.Type {
+	if len(schema.Value.Type) == 0 {
+		// Values of schemas without types, e.g. only constrained by a pattern, are kept as strings
+		return raw, nil
+	}
+	for _, typ := range schema.Value.Type {
+		if typ == "null" {
+			continue
+		}
+		if v, err = parsePrimitiveType(raw, schema, typ); err == nil {
+			return
+		}
+	}
+	return
+}
+
+func parsePrimitiveType(raw string, schema *openapi3.SchemaRef, typ string) (interface{}, error) {
+	switch typ {
 	case "integer":
 		if schema.Value.Format == "int32" {
 			v, err := strconv.ParseInt(raw, 0, 32)
 			if err != nil {
-				return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + schema.Value.Type, Cause: err.(*strconv.NumError).Err}
+				return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + typ, Cause: err.(*strconv.NumError).Err}
 			}
 			return int32(v), nil
 		}
 		v, err := strconv.ParseInt(raw, 0, 64)
 		if err != nil {
-			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + schema.Value.Type, Cause: err.(*strconv.NumError).Err}
+			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + typ, Cause: err.(*strconv.NumError).Err}
 		}
 		return v, nil
 	case "number":
 		v, err := strconv.ParseFloat(raw, 64)
 		if err != nil {
-			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + schema.Value.Type, Cause: err.(*strconv.NumError).Err}
+			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + typ, Cause: err.(*strconv.NumError).Err}
 		}
 		return v, nil
 	case "boolean":
 		v, err := strconv.ParseBool(raw)
 		if err != nil {
-			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + schema.Value.Type, Cause: err.(*strconv.NumError).Err}
+			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + typ, Cause: err.(*strconv.NumError).Err}
 		}
 		return v, nil
 	case "string":
 		return raw, nil
 	default:
-		panic(fmt.Sprintf("schema has non primitive type %q", schema.Value.Type))
+		panic(fmt.Sprintf("schema has non primitive type %q", typ))
 	}
 }
 
@@ -935,7 +957,7 @@ type EncodingFn func(partName string) *openapi3.Encoding
 type BodyDecoder func(io.Reader, http.Header, *openapi3.SchemaRef, EncodingFn) (interface{}, error)
 
 // bodyDecoders contains decoders for supported content types of a body.
-// By default, there is content type "application/json" is supported only.
+// Decoders for other content types (e.g. "text/xml") can be added with RegisterBodyDecoder.
 var bodyDecoders = make(map[string]BodyDecoder)
 
 // RegisteredBodyDecoder returns the registered body decoder for the given content type.
@@ -972,7 +994,11 @@ func UnregisterBodyDecoder(contentType string) {
 	delete(bodyDecoders, contentType)
 }
 
-var headerCT = http.CanonicalHeaderKey("Content-Type")
+var (
+	headerCT     = http.CanonicalHeaderKey("Content-Type")
+	headerCE     = http.CanonicalHeaderKey("Content-Encoding")
+	headerAccept = http.CanonicalHeaderKey("Accept")
+)
 
 const prefixUnsupportedCT = "unsupported content type"
 
@@ -1004,6 +1030,46 @@ func decodeBody(body io.Reader, header http.Header, schema *openapi3.SchemaRef,
 	return mediaType, value, nil
 }
 
+// readBody reads all of body, failing with ErrBodyTooLarge once more than max bytes are read when max is positive.
+func readBody(body io.Reader, max int64) ([]byte, error) {
+	if max <= 0 {
+		return ioutil.ReadAll(body)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, ErrBodyTooLarge
+	}
+	return data, nil
+}
+
+// decompressBody returns the decompressed data, which is compressed with contentEncoding.
+// Supported content encodings are gzip and deflate.
+// It fails with ErrBodyTooLarge once more than max bytes are decompressed when max is positive.
+func decompressBody(data []byte, contentEncoding string, max int64) ([]byte, error) {
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	switch encoding := strings.ToLower(strings.TrimSpace(contentEncoding)); encoding {
+	case "identity":
+		return data, nil
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(data))
+	case "deflate":
+		r, err = zlib.NewReader(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readBody(r, max)
+}
+
 func init() {
 	RegisterBodyDecoder("application/json", jsonBodyDecoder)
 	RegisterBodyDecoder("application/json-patch+json", jsonBodyDecoder)
@@ -1048,16 +1114,17 @@ func urlencodedBodyDecoder(body io.Reader, header http.Header, schema *openapi3.
 	// Validate schema of request body.
 	// By the OpenAPI 3 specification request body's schema must have type "object".
 	// Properties of the schema describes individual parts of request body.
-	if schema.Value.Type != "object" {
+	if !schema.Value.Type.Includes("object") {
 		return nil, errors.New("unsupported schema of request body")
 	}
 	for propName, propSchema := range schema.Value.Properties {
-		switch propSchema.Value.Type {
-		case "object":
+		propType := propSchema.Value.Type
+		switch {
+		case propType.Includes("object"):
 			return nil, fmt.Errorf("unsupported schema of request body's property %q", propName)
-		case "array":
+		case propType.Includes("array"):
 			items := propSchema.Value.Items.Value
-			if items.Type != "string" && items.Type != "integer" && items.Type != "number" && items.Type != "boolean" {
+			if len(items.Type) == 0 || items.Type.Includes("object") || items.Type.Includes("array") {
 				return nil, fmt.Errorf("unsupported schema of request body's property %q", propName)
 			}
 		}
@@ -1096,7 +1163,7 @@ func urlencodedBodyDecoder(body io.Reader, header http.Header, schema *openapi3.
 }
 
 func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
-	if schema.Value.Type != "object" {
+	if !schema.Value.Type.Includes("object") {
 		return nil, errors.New("unsupported schema of request body")
 	}
 
@@ -1159,7 +1226,7 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 					return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
 				}
 			}
-			if valueSchema.Value.Type == "array" {
+			if valueSchema.Value.Type.Includes("array") {
 				valueSchema = valueSchema.Value.Items
 			}
 		}
@@ -1204,7 +1271,7 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 		if len(vv) == 0 {
 			continue
 		}
-		if prop.Value.Type == "array" {
+		if prop.Value.Type.Includes("array") {
 			obj[name] = vv
 		} else {
 			obj[name] = vv[0]
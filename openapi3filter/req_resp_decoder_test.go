@@ -26,10 +26,10 @@ func TestDecodeParameter(t *testing.T) {
 		explode   = openapi3.BoolPtr(true)
 		noExplode = openapi3.BoolPtr(false)
 		arrayOf   = func(items *openapi3.SchemaRef) *openapi3.SchemaRef {
-			return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "array", Items: items}}
+			return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: openapi3.SchemaTypes{"array"}, Items: items}}
 		}
 		objectOf = func(args ...interface{}) *openapi3.SchemaRef {
-			s := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "object", Properties: make(map[string]*openapi3.SchemaRef)}}
+			s := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: openapi3.SchemaTypes{"object"}, Properties: make(map[string]*openapi3.SchemaRef)}}
 			if len(args)%2 != 0 {
 				panic("invalid arguments. must be an even number of arguments")
 			}
@@ -41,10 +41,10 @@ func TestDecodeParameter(t *testing.T) {
 			return s
 		}
 
-		integerSchema = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "integer"}}
-		numberSchema  = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "number"}}
-		booleanSchema = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "boolean"}}
-		stringSchema  = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string"}}
+		integerSchema = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: openapi3.SchemaTypes{"integer"}}}
+		numberSchema  = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: openapi3.SchemaTypes{"number"}}}
+		booleanSchema = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: openapi3.SchemaTypes{"boolean"}}}
+		stringSchema  = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: openapi3.SchemaTypes{"string"}}}
 		allofSchema   = &openapi3.SchemaRef{
 			Value: &openapi3.Schema{
 				AllOf: []*openapi3.SchemaRef{
@@ -1049,7 +1049,7 @@ func TestDecodeParameter(t *testing.T) {
 						Parameters:  []*openapi3.ParameterRef{{Value: tc.param}},
 						Responses:   openapi3.NewResponses(),
 					}
-					doc.AddOperation(path, http.MethodGet, op)
+					require.NoError(t, doc.AddOperation(path, http.MethodGet, op))
 					err = doc.Validate(context.Background())
 					require.NoError(t, err)
 					router, err := legacyrouter.NewRouter(doc)
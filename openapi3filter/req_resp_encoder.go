@@ -16,12 +16,24 @@ func encodeBody(body interface{}, mediaType string) ([]byte, error) {
 	return encoder(body)
 }
 
+// BodyEncoder encodes a body value into the bytes of a content type.
+//
+// Body encoders are used to write back a body once default values were set during validation.
+// They are the counterpart of body decoders registered with RegisterBodyDecoder.
 type BodyEncoder func(body interface{}) ([]byte, error)
 
+// bodyEncoders contains encoders for supported content types of a body.
 var bodyEncoders = map[string]BodyEncoder{
-	"application/json": json.Marshal,
+	"application/json":            json.Marshal,
+	"application/json-patch+json": json.Marshal,
+	"application/problem+json":    json.Marshal,
 }
 
+// RegisterBodyEncoder enables package-wide encoding of contentType values.
+//
+// If an encoder for the specified content type already exists, the function replaces
+// it with the specified encoder.
+// This call is not thread-safe: body encoders should not be created/destroyed by multiple goroutines.
 func RegisterBodyEncoder(contentType string, encoder BodyEncoder) {
 	if contentType == "" {
 		panic("contentType is empty")
@@ -32,6 +44,9 @@ func RegisterBodyEncoder(contentType string, encoder BodyEncoder) {
 	bodyEncoders[contentType] = encoder
 }
 
+// UnregisterBodyEncoder dissociates a body encoder from a content type.
+//
+// Encoding this content type will result in an error.
 // This call is not thread-safe: body encoders should not be created/destroyed by multiple goroutines.
 func UnregisterBodyEncoder(contentType string) {
 	if contentType == "" {
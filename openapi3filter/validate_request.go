@@ -6,11 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
 )
 
 // ErrAuthenticationServiceMissing is returned when no authentication service
@@ -23,6 +24,13 @@ var ErrInvalidRequired = errors.New("value is required but missing")
 // ErrInvalidEmptyValue is returned when a value of a parameter or request body is empty while it's not allowed.
 var ErrInvalidEmptyValue = errors.New("empty value is not allowed")
 
+// ErrBodyTooLarge is returned when a request or response body is larger than Options.MaximumBodySize.
+var ErrBodyTooLarge = errors.New("body is too large")
+
+// ErrNotAcceptable is passed to Options.WarningFunc when none of the media types of the request's
+// Accept header is produced by the responses of its operation, see Options.WithValidateAcceptHeader.
+var ErrNotAcceptable = errors.New("none of the accepted media types is produced by the operation")
+
 // ValidateRequest is used to validate the given input according to previous
 // loaded OpenAPIv3 spec. If the input does not match the OpenAPIv3 spec, a
 // non-nil error will be returned.
@@ -31,6 +39,7 @@ var ErrInvalidEmptyValue = errors.New("empty value is not allowed")
 // by registering a custom function with openapi3.RegisterArrayUniqueItemsChecker
 func ValidateRequest(ctx context.Context, input *RequestValidationInput) (err error) {
 	var me openapi3.MultiError
+	defer func() { setMatchedRoute(err, input.Route) }()
 
 	options := input.Options
 	if options == nil {
@@ -95,12 +104,33 @@ func ValidateRequest(ctx context.Context, input *RequestValidationInput) (err er
 		}
 	}
 
+	if options.acceptHeaderValidated {
+		validateAcceptHeader(ctx, input, options)
+	}
+
 	if len(me) > 0 {
 		return me
 	}
 	return
 }
 
+// setMatchedRoute sets the route of the request errors found in err.
+func setMatchedRoute(err error, route *routers.Route) {
+	switch err := err.(type) {
+	case *RequestError:
+		err.RouteMatched = true
+		err.MatchedRoute = route
+	case openapi3.MultiError:
+		for _, e := range err {
+			setMatchedRoute(e, route)
+		}
+	case *SecurityRequirementsError:
+		for _, e := range err.Errors {
+			setMatchedRoute(e, route)
+		}
+	}
+}
+
 // ValidateParameter validates a parameter's value by JSON schema.
 // The function returns RequestError with a ParseError cause when unable to parse a value.
 // The function returns RequestError with ErrInvalidRequired cause when a value of a required parameter is not defined.
@@ -129,6 +159,14 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 		if value, schema, found, err = decodeContentParameter(parameter, input); err != nil {
 			return &RequestError{Input: input, Parameter: parameter, Err: err}
 		}
+	} else if decode := options.getPathParamDecoder(parameter); decode != nil {
+		schema = parameter.Schema.Value
+		var raw string
+		if raw, found = input.PathParams[parameter.Name]; found {
+			if value, err = decode(schema, raw); err != nil {
+				return &RequestError{Input: input, Parameter: parameter, Err: err}
+			}
+		}
 	} else {
 		if value, found, err = decodeStyledParameter(parameter, input); err != nil {
 			return &RequestError{Input: input, Parameter: parameter, Err: err}
@@ -167,16 +205,20 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 		if !parameter.AllowEmptyValue && found {
 			return &RequestError{Input: input, Parameter: parameter, Reason: ErrInvalidEmptyValue.Error(), Err: ErrInvalidEmptyValue}
 		}
-		return nil
+		if !found || schema == nil || !schema.Type.Includes(openapi3.TypeString) {
+			return nil
+		}
+		// An allowed empty value of a string parameter is still constrained by its schema (e.g. minLength)
+		value = ""
 	}
 	if schema == nil {
 		// A parameter's schema is not defined so skip validation of a parameter's value.
 		return nil
 	}
 
-	var opts []openapi3.SchemaValidationOption
+	opts := make([]openapi3.SchemaValidationOption, 0, 3) // 3 potential opts here
+	opts = append(opts, openapi3.WithContext(ctx))
 	if options.MultiError {
-		opts = make([]openapi3.SchemaValidationOption, 0, 1)
 		opts = append(opts, openapi3.MultiErrors())
 	}
 	if options.customSchemaErrorFunc != nil {
@@ -188,6 +230,41 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 	return nil
 }
 
+// validateAcceptHeader passes a RequestError to options.WarningFunc when none of the media types
+// accepted by the request is produced by the responses of its operation.
+// Content negotiation is up to the handler, which may still respond with an acceptable representation.
+func validateAcceptHeader(ctx context.Context, input *RequestValidationInput, options *Options) {
+	accept := input.Request.Header.Values(headerAccept)
+	if len(accept) == 0 || options.WarningFunc == nil {
+		return
+	}
+	ranges := parseAcceptHeader(strings.Join(accept, ","))
+
+	var produced []string
+	for _, response := range input.Route.Operation.Responses {
+		if response == nil || response.Value == nil {
+			continue
+		}
+		for mediaType := range response.Value.Content {
+			produced = append(produced, parseMediaType(mediaType))
+		}
+	}
+	if len(produced) == 0 {
+		return
+	}
+
+	for _, mediaType := range produced {
+		if ranges.quality(mediaType) > 0 {
+			return
+		}
+	}
+	options.WarningFunc(ctx, &RequestError{
+		Input:  input,
+		Reason: fmt.Sprintf("header Accept %q", strings.Join(accept, ", ")),
+		Err:    ErrNotAcceptable,
+	})
+}
+
 const prefixInvalidCT = "header Content-Type has unexpected value"
 
 // ValidateRequestBody validates data of a request's body.
@@ -205,10 +282,12 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		options = &Options{}
 	}
 
-	if req.Body != http.NoBody && req.Body != nil {
+	emptyBody := req.Body == http.NoBody || req.Body == nil ||
+		(options.SkipEmptyRequestBody && req.ContentLength == 0)
+	if !emptyBody {
 		defer req.Body.Close()
 		var err error
-		if data, err = ioutil.ReadAll(req.Body); err != nil {
+		if data, err = readBody(req.Body, options.MaximumBodySize); err != nil {
 			return &RequestError{
 				Input:       input,
 				RequestBody: requestBody,
@@ -234,7 +313,13 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 
 	if len(data) == 0 {
 		if requestBody.Required {
-			return &RequestError{Input: input, RequestBody: requestBody, Err: ErrInvalidRequired}
+			err := &RequestError{Input: input, RequestBody: requestBody, Err: ErrInvalidRequired}
+			if !options.AllowMissingRequiredRequestBody {
+				return err
+			}
+			if options.WarningFunc != nil {
+				options.WarningFunc(ctx, err)
+			}
 		}
 		return nil
 	}
@@ -245,6 +330,20 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		return nil
 	}
 
+	body := data
+	contentEncoding := req.Header.Get(headerCE)
+	if options.DecompressRequestBody && contentEncoding != "" {
+		var err error
+		if body, err = decompressBody(data, contentEncoding, options.MaximumBodySize); err != nil {
+			return &RequestError{
+				Input:       input,
+				RequestBody: requestBody,
+				Reason:      "decompression failed",
+				Err:         err,
+			}
+		}
+	}
+
 	inputMIME := req.Header.Get(headerCT)
 	contentType := requestBody.Content.Get(inputMIME)
 	if contentType == nil {
@@ -261,7 +360,7 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 	}
 
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
-	mediaType, value, err := decodeBody(bytes.NewReader(data), req.Header, contentType.Schema, encFn)
+	mediaType, value, err := decodeBody(bytes.NewReader(body), req.Header, contentType.Schema, encFn)
 	if err != nil {
 		return &RequestError{
 			Input:       input,
@@ -272,8 +371,8 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 	}
 
 	defaultsSet := false
-	opts := make([]openapi3.SchemaValidationOption, 0, 4) // 4 potential opts here
-	opts = append(opts, openapi3.VisitAsRequest())
+	opts := make([]openapi3.SchemaValidationOption, 0, 6) // 6 potential opts here
+	opts = append(opts, openapi3.VisitAsRequest(), openapi3.WithContext(ctx))
 	if !options.SkipSettingDefaults {
 		opts = append(opts, openapi3.DefaultsSet(func() { defaultsSet = true }))
 	}
@@ -313,6 +412,10 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		if req.Body != nil {
 			req.Body.Close()
 		}
+		if options.DecompressRequestBody {
+			// The rewritten body is not compressed
+			req.Header.Del(headerCE)
+		}
 		req.ContentLength = int64(len(data))
 		req.GetBody = func() (io.ReadCloser, error) {
 			return io.NopCloser(bytes.NewReader(data)), nil
@@ -390,6 +493,7 @@ func validateSecurityRequirement(ctx context.Context, input *RequestValidationIn
 			SecuritySchemeName:     name,
 			SecurityScheme:         securityScheme,
 			Scopes:                 scopes,
+			Token:                  securityToken(input.Request, securityScheme),
 		}); err != nil {
 			return err
 		}
@@ -28,6 +28,24 @@ type RequestValidationInput struct {
 	ParamDecoder ContentParameterDecoder
 }
 
+// NewRequestValidationInput returns the input validating req against route,
+// with the path parameters found by the router and the default Options.
+// It panics if req or route is nil.
+func NewRequestValidationInput(req *http.Request, route *routers.Route, pathParams map[string]string) *RequestValidationInput {
+	if req == nil {
+		panic("openapi3filter: NewRequestValidationInput called with a nil request")
+	}
+	if route == nil {
+		panic("openapi3filter: NewRequestValidationInput called with a nil route")
+	}
+	return &RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    &Options{},
+	}
+}
+
 func (input *RequestValidationInput) GetQueryParams() url.Values {
 	q := input.QueryParams
 	if q == nil {
@@ -2,13 +2,18 @@ package openapi3filter
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +38,31 @@ func setupTestRouter(t *testing.T, spec string) routers.Router {
 	return router
 }
 
+// requestValidationInputFor returns the input validating a request of method to target,
+// with the given body and header, against the route router finds for it.
+// Options are left to their defaults when options is nil.
+func requestValidationInputFor(t *testing.T, router routers.Router, method, target string, body io.Reader, header http.Header, options *Options) *RequestValidationInput {
+	t.Helper()
+	req, err := http.NewRequest(method, target, body)
+	require.NoError(t, err)
+	if header != nil {
+		req.Header = header.Clone()
+	}
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+	input := NewRequestValidationInput(req, route, pathParams)
+	if options != nil {
+		input.Options = options
+	}
+	return input
+}
+
+// validateRequestFor validates a request built like requestValidationInputFor does.
+func validateRequestFor(t *testing.T, router routers.Router, method, target string, body io.Reader, header http.Header, options *Options) error {
+	t.Helper()
+	return ValidateRequest(context.Background(), requestValidationInputFor(t, router, method, target, body, header, options))
+}
+
 func TestValidateRequest(t *testing.T) {
 	const spec = `
 openapi: 3.0.0
@@ -182,25 +212,14 @@ components:
 				requestBody = bytes.NewReader(testingBody)
 				originalBodySize = len(testingBody)
 			}
-			req, err := http.NewRequest(http.MethodPost, tc.args.url, requestBody)
-			require.NoError(t, err)
-			req.Header.Add("Content-Type", "application/json")
+			header := http.Header{"Content-Type": {"application/json"}}
 			if tc.args.apiKey != "" {
-				req.Header.Add("Api-Key", tc.args.apiKey)
-			}
-
-			route, pathParams, err := router.FindRoute(req)
-			require.NoError(t, err)
-
-			validationInput := &RequestValidationInput{
-				Request:    req,
-				PathParams: pathParams,
-				Route:      route,
-				Options: &Options{
-					AuthenticationFunc: verifyAPIKeyPresence,
-				},
+				header.Set("Api-Key", tc.args.apiKey)
 			}
-			err = ValidateRequest(context.Background(), validationInput)
+			validationInput := requestValidationInputFor(t, router, http.MethodPost, tc.args.url, requestBody, header, &Options{
+				AuthenticationFunc: verifyAPIKeyPresence,
+			})
+			err := ValidateRequest(context.Background(), validationInput)
 			assert.IsType(t, tc.expectedErr, err, "ValidateRequest(): error = %v, expectedError %v", err, tc.expectedErr)
 			if tc.expectedErr != nil {
 				return
@@ -221,3 +240,793 @@ components:
 		})
 	}
 }
+
+func TestValidateRequestContextDeadline(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: array
+              items:
+                type: object
+                properties:
+                  id:
+                    type: integer
+                  name:
+                    type: string
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	var body bytes.Buffer
+	body.WriteString("[")
+	for i := 0; i < 100000; i++ {
+		if i != 0 {
+			body.WriteString(",")
+		}
+		fmt.Fprintf(&body, `{"id":%d,"name":"item%d"}`, i, i)
+	}
+	body.WriteString("]")
+
+	newInput := func() *RequestValidationInput {
+		header := http.Header{"Content-Type": {"application/json"}}
+		return requestValidationInputFor(t, router, http.MethodPost, "/items", bytes.NewReader(body.Bytes()), header, &Options{MultiError: true})
+	}
+
+	err := ValidateRequest(context.Background(), newInput())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err = ValidateRequest(ctx, newInput())
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestValidateRequestDecompressBody(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                count:
+                  type: integer
+                  default: 1
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	compress := func(encoding, data string) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		}
+		_, err := w.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	newInput := func(encoding string, body []byte, options *Options) *RequestValidationInput {
+		header := http.Header{"Content-Type": {"application/json"}, "Content-Encoding": {encoding}}
+		return requestValidationInputFor(t, router, http.MethodPost, "/items", bytes.NewReader(body), header, options)
+	}
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		body := compress(encoding, `{"name":"foo"}`)
+
+		err := ValidateRequest(context.Background(), newInput(encoding, body, &Options{SkipSettingDefaults: true}))
+		require.ErrorContains(t, err, "failed to decode request body", encoding)
+
+		input := newInput(encoding, body, &Options{DecompressRequestBody: true, SkipSettingDefaults: true})
+		err = ValidateRequest(context.Background(), input)
+		require.NoError(t, err, encoding)
+		rewritten, err := io.ReadAll(input.Request.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, rewritten, encoding)
+
+		input = newInput(encoding, body, &Options{DecompressRequestBody: true})
+		err = ValidateRequest(context.Background(), input)
+		require.NoError(t, err, encoding)
+		rewritten, err = io.ReadAll(input.Request.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"foo","count":1}`, string(rewritten), encoding)
+		require.Empty(t, input.Request.Header.Get("Content-Encoding"), encoding)
+
+		err = ValidateRequest(context.Background(), newInput(encoding, compress(encoding, `{}`), &Options{DecompressRequestBody: true}))
+		require.ErrorContains(t, err, `property "name" is missing`, encoding)
+
+		// The limit also applies to the decompressed body
+		bomb := compress(encoding, `{"name":"`+strings.Repeat("a", 1<<20)+`"}`)
+		require.Less(t, len(bomb), 4096, encoding)
+		err = ValidateRequest(context.Background(), newInput(encoding, bomb, &Options{DecompressRequestBody: true, MaximumBodySize: 4096}))
+		require.ErrorIs(t, err, ErrBodyTooLarge, encoding)
+		require.ErrorContains(t, err, "decompression failed", encoding)
+	}
+
+	err := ValidateRequest(context.Background(), newInput("br", []byte(`{"name":"foo"}`), &Options{DecompressRequestBody: true}))
+	require.ErrorContains(t, err, `decompression failed: unsupported content encoding "br"`)
+}
+
+type unreadBody struct {
+	io.Reader
+	read bool
+}
+
+func (b *unreadBody) Read(p []byte) (int, error) {
+	b.read = true
+	return b.Reader.Read(p)
+}
+
+func (b *unreadBody) Close() error { return nil }
+
+func TestValidateRequestSkipEmptyRequestBody(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    delete:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: Deleted
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(method string, options *Options) (*unreadBody, *http.Request, error) {
+		body := &unreadBody{Reader: strings.NewReader("")}
+		input := requestValidationInputFor(t, router, method, "/items", body, nil, options)
+		require.Zero(t, input.Request.ContentLength)
+		err := ValidateRequest(context.Background(), input)
+		return body, input.Request, err
+	}
+
+	body, req, err := validate(http.MethodDelete, &Options{})
+	require.NoError(t, err)
+	require.True(t, body.read)
+	require.NotSame(t, body, req.Body)
+
+	body, req, err = validate(http.MethodDelete, &Options{SkipEmptyRequestBody: true})
+	require.NoError(t, err)
+	require.False(t, body.read)
+	require.Same(t, body, req.Body)
+
+	body, _, err = validate(http.MethodPost, &Options{SkipEmptyRequestBody: true})
+	require.ErrorIs(t, err, ErrInvalidRequired)
+	require.False(t, body.read)
+}
+
+func TestValidateMaximumBodySize(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+`
+
+	router := setupTestRouter(t, spec)
+	options := &Options{MaximumBodySize: 16}
+
+	newInput := func(body string) *RequestValidationInput {
+		header := http.Header{"Content-Type": {"application/json"}}
+		return requestValidationInputFor(t, router, http.MethodPost, "/items", strings.NewReader(body), header, options)
+	}
+
+	err := ValidateRequest(context.Background(), newInput(`{"a":"01234567"}`))
+	require.NoError(t, err)
+
+	err = ValidateRequest(context.Background(), newInput(`{"a":"012345678"}`))
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+	var validationErr *ValidationError
+	require.True(t, errors.As(ConvertErrors(err), &validationErr))
+	require.Equal(t, http.StatusRequestEntityTooLarge, validationErr.Status)
+
+	validateResponse := func(body string) error {
+		input := &ResponseValidationInput{
+			RequestValidationInput: newInput(`{}`),
+			Status:                 http.StatusOK,
+			Header:                 http.Header{"Content-Type": []string{"application/json"}},
+			Options:                options,
+		}
+		input.SetBodyBytes([]byte(body))
+		return ValidateResponse(context.Background(), input)
+	}
+
+	err = validateResponse(`{"a":"01234567"}`)
+	require.NoError(t, err)
+
+	err = validateResponse(`{"a":"012345678"}`)
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func TestValidateRequestMalformedJSONBody(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(body string) error {
+		header := http.Header{"Content-Type": {"application/json"}}
+		return validateRequestFor(t, router, http.MethodPost, "/items", strings.NewReader(body), header, nil)
+	}
+
+	for _, body := range []string{`{"name":`, `{"name" "x"}`, `not json`} {
+		err := validate(body)
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr, body)
+		require.Equal(t, KindInvalidFormat, parseErr.Kind, body)
+		require.NotNil(t, parseErr.RootCause(), body)
+		var schemaErr *openapi3.SchemaError
+		require.False(t, errors.As(err, &schemaErr), body)
+	}
+
+	err := validate(`"a string"`)
+	var schemaErr *openapi3.SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	var parseErr *ParseError
+	require.False(t, errors.As(err, &parseErr))
+}
+
+func TestValidateSecurityRequirementsToken(t *testing.T) {
+	schemes := openapi3.SecuritySchemes{
+		"header": &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName("X-Api-Key")},
+		"query":  &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("query").WithName("api_key")},
+		"cookie": &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("cookie").WithName("session")},
+		"bearer": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+		"basic":  &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("http").WithScheme("basic")},
+	}
+	route := &routers.Route{Spec: &openapi3.T{Components: &openapi3.Components{SecuritySchemes: schemes}}}
+
+	tests := []struct {
+		scheme string
+		setup  func(req *http.Request)
+		token  string
+	}{
+		{"header", func(req *http.Request) { req.Header.Set("X-Api-Key", "key1") }, "key1"},
+		{"query", func(req *http.Request) { req.URL.RawQuery = "api_key=key2" }, "key2"},
+		{"cookie", func(req *http.Request) { req.AddCookie(&http.Cookie{Name: "session", Value: "key3"}) }, "key3"},
+		{"bearer", func(req *http.Request) { req.Header.Set("Authorization", "Bearer abc.def.ghi") }, "abc.def.ghi"},
+		{"bearer", func(req *http.Request) { req.Header.Set("Authorization", "bearer abc") }, "abc"},
+		{"bearer", func(req *http.Request) { req.Header.Set("Authorization", "Basic dXNlcjpwYXNz") }, ""},
+		{"basic", func(req *http.Request) { req.Header.Set("Authorization", "Basic dXNlcjpwYXNz") }, "dXNlcjpwYXNz"},
+		{"header", func(req *http.Request) {}, ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+		tt.setup(req)
+
+		var token string
+		input := &RequestValidationInput{
+			Request: req,
+			Route:   route,
+			Options: &Options{AuthenticationFunc: func(ctx context.Context, input *AuthenticationInput) error {
+				token = input.Token
+				return nil
+			}},
+		}
+		err = ValidateSecurityRequirements(context.Background(), input, openapi3.SecurityRequirements{{tt.scheme: {}}})
+		require.NoError(t, err)
+		require.Equal(t, tt.token, token, tt.scheme)
+	}
+}
+
+func TestNewRequestValidationInput(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+      responses:
+        '200':
+          description: OK
+`
+
+	router := setupTestRouter(t, spec)
+
+	input := requestValidationInputFor(t, router, http.MethodGet, "/items/42", nil, nil, nil)
+	require.Equal(t, "/items/{id}", input.Route.Path)
+	require.Equal(t, map[string]string{"id": "42"}, input.PathParams)
+	require.Equal(t, &Options{}, input.Options)
+	require.NoError(t, ValidateRequest(context.Background(), input))
+
+	require.PanicsWithValue(t, "openapi3filter: NewRequestValidationInput called with a nil request", func() {
+		NewRequestValidationInput(nil, input.Route, input.PathParams)
+	})
+	require.PanicsWithValue(t, "openapi3filter: NewRequestValidationInput called with a nil route", func() {
+		NewRequestValidationInput(input.Request, nil, input.PathParams)
+	})
+}
+
+func TestValidateRequestErrorMatchedRoute(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+      - name: q
+        in: query
+        required: true
+        schema:
+          type: string
+      responses:
+        '200':
+          description: OK
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(target string, options *Options) error {
+		err := validateRequestFor(t, router, http.MethodGet, target, nil, nil, options)
+		require.Error(t, err)
+		return err
+	}
+
+	err := validate("/items/abc", &Options{})
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	require.True(t, requestErr.RouteMatched)
+	require.NotNil(t, requestErr.MatchedRoute)
+	require.Equal(t, "/items/{id}", requestErr.MatchedRoute.Path)
+	require.Equal(t, http.MethodGet, requestErr.MatchedRoute.Method)
+
+	err = validate("/items/abc", &Options{MultiError: true})
+	var me openapi3.MultiError
+	require.ErrorAs(t, err, &me)
+	require.Len(t, me, 2)
+	for _, e := range me {
+		requestErr, ok := e.(*RequestError)
+		require.True(t, ok)
+		require.True(t, requestErr.RouteMatched)
+		require.Equal(t, "/items/{id}", requestErr.MatchedRoute.Path)
+	}
+}
+
+func TestValidateRequestBodyContentTypeParameters(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+          text/*:
+            schema:
+              type: string
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(contentType, body string) error {
+		header := http.Header{"Content-Type": {contentType}}
+		return validateRequestFor(t, router, http.MethodPost, "/items", strings.NewReader(body), header, nil)
+	}
+
+	for _, contentType := range []string{
+		"application/json",
+		"application/json; charset=utf-8",
+		"application/json ;charset=utf-8",
+		"Application/JSON; charset=utf-8",
+	} {
+		require.NoError(t, validate(contentType, `{"name": "foo"}`), contentType)
+
+		err := validate(contentType, `{}`)
+		var schemaErr *openapi3.SchemaError
+		require.ErrorAs(t, err, &schemaErr, contentType)
+	}
+
+	require.NoError(t, validate("text/plain; charset=utf-8", `foo`))
+
+	err := validate("application/xml; charset=utf-8", `<name>foo</name>`)
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	require.Equal(t, `header Content-Type has unexpected value "application/xml; charset=utf-8"`, requestErr.Reason)
+}
+
+func TestValidateRequestAllowMissingRequiredRequestBody(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(body string, options *Options) error {
+		header := http.Header{"Content-Type": {"application/json"}}
+		return validateRequestFor(t, router, http.MethodPost, "/items", strings.NewReader(body), header, options)
+	}
+
+	err := validate("", &Options{})
+	require.ErrorIs(t, err, ErrInvalidRequired)
+
+	var warnings []error
+	options := &Options{
+		AllowMissingRequiredRequestBody: true,
+		WarningFunc: func(ctx context.Context, err error) {
+			warnings = append(warnings, err)
+		},
+	}
+	require.NoError(t, validate("", options))
+	require.Len(t, warnings, 1)
+	require.ErrorIs(t, warnings[0], ErrInvalidRequired)
+
+	require.NoError(t, validate(`{}`, options))
+	require.Len(t, warnings, 1)
+	require.Error(t, validate(`[]`, options))
+
+	require.NoError(t, validate("", &Options{AllowMissingRequiredRequestBody: true}))
+}
+
+func TestValidateRequestPathParameterPattern(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /users/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+          pattern: '^[0-9]+$'
+      responses:
+        '200':
+          description: OK
+  /groups/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          pattern: '^g-[a-z]+$'
+      responses:
+        '200':
+          description: OK
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(target string) error {
+		return validateRequestFor(t, router, http.MethodGet, target, nil, nil, nil)
+	}
+
+	for target, valid := range map[string]bool{
+		"/users/42":    true,
+		"/users/abc":   false,
+		"/users/4a2":   false,
+		"/groups/g-ab": true,
+		"/groups/ab":   false,
+	} {
+		t.Run(target, func(t *testing.T) {
+			err := validate(target)
+			if valid {
+				require.NoError(t, err)
+				return
+			}
+			var requestErr *RequestError
+			require.ErrorAs(t, err, &requestErr)
+			require.Equal(t, "id", requestErr.Parameter.Name)
+			require.ErrorContains(t, err, "string doesn't match the regular expression")
+		})
+	}
+}
+
+func TestValidateRequestQueryParameterLength(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /users:
+    get:
+      parameters:
+      - name: name
+        in: query
+        schema:
+          type: string
+          minLength: 1
+          maxLength: 5
+      - name: nickname
+        in: query
+        allowEmptyValue: true
+        schema:
+          type: string
+          minLength: 1
+      responses:
+        '200':
+          description: OK
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(target string) error {
+		return validateRequestFor(t, router, http.MethodGet, target, nil, nil, nil)
+	}
+
+	require.NoError(t, validate("/users?name=bob"))
+	require.NoError(t, validate("/users?name=alice"))
+
+	err := validate("/users?name=")
+	require.ErrorContains(t, err, `parameter "name" in query has an error: empty value is not allowed`)
+
+	err = validate("/users?name=verylongname")
+	require.ErrorContains(t, err, `parameter "name" in query has an error: maximum string length is 5`)
+
+	err = validate("/users?nickname=")
+	require.ErrorContains(t, err, `parameter "nickname" in query has an error: minimum string length is 1`)
+}
+
+func TestValidateRequestAcceptHeader(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /items:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+        default:
+          description: Error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+`
+
+	router := setupTestRouter(t, spec)
+
+	var warnings []error
+	options := &Options{
+		WarningFunc: func(ctx context.Context, err error) {
+			warnings = append(warnings, err)
+		},
+	}
+	options.WithValidateAcceptHeader(true)
+
+	validate := func(accept string, options *Options) error {
+		header := http.Header{}
+		if accept != "" {
+			header.Set("Accept", accept)
+		}
+		return validateRequestFor(t, router, http.MethodGet, "/items", nil, header, options)
+	}
+
+	for accept, acceptable := range map[string]bool{
+		"":                                true,
+		"application/json":                true,
+		"Application/JSON; charset=utf-8": true,
+		"application/*":                   true,
+		"*/*":                             true,
+		"text/html, application/xml;q=0.9, */*;q=0.8": true,
+		"application/problem+json":                    true,
+		"application/xml":                             false,
+		"text/*, application/xml":                     false,
+		"application/json;q=0":                        false,
+		"*/*;q=0.5, application/*;q=0":                false,
+		"application/json;q=oops":                     false,
+	} {
+		t.Run(accept, func(t *testing.T) {
+			warnings = nil
+			require.NoError(t, validate(accept, options))
+			if acceptable {
+				require.Empty(t, warnings)
+				return
+			}
+			require.Len(t, warnings, 1)
+			require.ErrorIs(t, warnings[0], ErrNotAcceptable)
+			require.EqualError(t, warnings[0], fmt.Sprintf("header Accept %q: none of the accepted media types is produced by the operation", accept))
+		})
+	}
+
+	// Disabled by default
+	warnings = nil
+	require.NoError(t, validate("application/xml", &Options{WarningFunc: options.WarningFunc}))
+	require.Empty(t, warnings)
+}
+
+func TestValidateRequestWithPathParamDecoder(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /files/{name}/versions/{version}:
+    get:
+      parameters:
+      - name: name
+        in: path
+        required: true
+        schema:
+          type: string
+          pattern: '^[a-z]+\.txt$'
+      - name: version
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 1
+      responses:
+        '200':
+          description: OK
+`
+
+	router := setupTestRouter(t, spec)
+
+	base64URL := func(schema *openapi3.Schema, value string) (interface{}, error) {
+		decoded, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(decoded), nil
+	}
+
+	validate := func(target string, options *Options) error {
+		return validateRequestFor(t, router, http.MethodGet, target, nil, nil, options)
+	}
+
+	name := base64.RawURLEncoding.EncodeToString([]byte("notes.txt"))
+	err := validate("/files/"+name+"/versions/2", &Options{})
+	require.ErrorContains(t, err, `parameter "name" in path has an error: string doesn't match the regular expression`)
+
+	options := &Options{}
+	options.WithPathParamDecoder(base64URL, "name")
+	require.NoError(t, validate("/files/"+name+"/versions/2", options))
+
+	err = validate("/files/"+name+"/versions/0", options)
+	require.ErrorContains(t, err, `parameter "version" in path has an error: number must be at least 1`)
+
+	err = validate("/files/!!/versions/2", options)
+	require.ErrorContains(t, err, `parameter "name" in path has an error: illegal base64 data`)
+
+	bad := base64.RawURLEncoding.EncodeToString([]byte("Notes.md"))
+	err = validate("/files/"+bad+"/versions/2", options)
+	require.ErrorContains(t, err, `parameter "name" in path has an error: string doesn't match the regular expression`)
+
+	// A decoder of all path parameters applies to the others
+	options.WithPathParamDecoder(func(schema *openapi3.Schema, value string) (interface{}, error) {
+		return float64(len(value)), nil
+	})
+	require.NoError(t, validate("/files/"+name+"/versions/abc", options))
+}
@@ -5,7 +5,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"sort"
 	"strings"
@@ -26,6 +25,16 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return nil
 	}
 	status := input.Status
+	options := input.Options
+	if options == nil {
+		options = &Options{}
+	}
+
+	// Informational responses are not final, they are only validated in strict mode.
+	informational := status >= 100 && status < 200
+	if informational && !options.Strict1xxHandling {
+		return nil
+	}
 
 	// These status codes will never be validated.
 	// TODO: The list is probably missing some.
@@ -37,10 +46,6 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return nil
 	}
 	route := input.RequestValidationInput.Route
-	options := input.Options
-	if options == nil {
-		options = &Options{}
-	}
 
 	// Find input for the current status
 	responses := route.Operation.Responses
@@ -48,6 +53,10 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return nil
 	}
 	responseRef := responses.Get(status) // Response
+	if responseRef == nil && informational {
+		// The default response only describes final responses.
+		return &ResponseError{Input: input, Reason: "informational status is not documented"}
+	}
 	if responseRef == nil {
 		responseRef = responses.Default() // Default input
 	}
@@ -63,7 +72,8 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return &ResponseError{Input: input, Reason: "response has not been resolved"}
 	}
 
-	opts := make([]openapi3.SchemaValidationOption, 0, 3) // 3 potential options here
+	opts := make([]openapi3.SchemaValidationOption, 0, 4) // 4 potential options here
+	opts = append(opts, openapi3.WithContext(ctx))
 	if options.MultiError {
 		opts = append(opts, openapi3.MultiErrors())
 	}
@@ -93,6 +103,11 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return nil
 	}
 
+	if !statusAllowsBody(status) {
+		// Responses with this status code never have a body, whatever the document says.
+		return nil
+	}
+
 	content := response.Content
 	if len(content) == 0 || options.ExcludeResponseBody {
 		// An operation does not contains a validation schema for responses with this status code.
@@ -125,7 +140,7 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 	defer body.Close()
 
 	// Read all
-	data, err := ioutil.ReadAll(body)
+	data, err := readBody(body, options.MaximumBodySize)
 	if err != nil {
 		return &ResponseError{
 			Input:  input,
@@ -160,6 +175,18 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 	return nil
 }
 
+// statusAllowsBody reports whether a response with this status code may have a body,
+// see https://www.rfc-editor.org/rfc/rfc9110#section-6.4.1
+func statusAllowsBody(status int) bool {
+	switch {
+	case status >= 100 && status < 200:
+		return false
+	case status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
 func validateResponseHeader(headerName string, headerRef *openapi3.HeaderRef, input *ResponseValidationInput, opts []openapi3.SchemaValidationOption) error {
 	var err error
 	var decodedValue interface{}
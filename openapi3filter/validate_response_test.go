@@ -1,6 +1,7 @@
 package openapi3filter
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -213,3 +214,86 @@ func newArraySchema(schema *openapi3.Schema) *openapi3.Schema {
 
 	return arraySchema
 }
+
+func TestValidateResponseWithoutBody(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+components:
+  responses:
+    Item:
+      description: An item
+      headers:
+        X-Item-Id:
+          required: true
+          schema:
+            type: integer
+      content:
+        application/json:
+          schema:
+            type: object
+            required: [id]
+paths:
+  /items:
+    get:
+      responses:
+        '100':
+          $ref: '#/components/responses/Item'
+        '103':
+          $ref: '#/components/responses/Item'
+        '200':
+          $ref: '#/components/responses/Item'
+        '204':
+          $ref: '#/components/responses/Item'
+        '304':
+          $ref: '#/components/responses/Item'
+`
+
+	router := setupTestRouter(t, spec)
+	req, err := http.NewRequest(http.MethodGet, "/items", nil)
+	require.NoError(t, err)
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	validate := func(status int, header http.Header) error {
+		return ValidateResponse(context.Background(), &ResponseValidationInput{
+			RequestValidationInput: NewRequestValidationInput(req, route, pathParams),
+			Status:                 status,
+			Header:                 header,
+			Body:                   http.NoBody,
+		})
+	}
+
+	for _, status := range []int{100, 103, 204, 304} {
+		require.NoError(t, validate(status, http.Header{"X-Item-Id": {"1"}}), status)
+	}
+
+	err = validate(http.StatusOK, http.Header{"X-Item-Id": {"1"}})
+	require.ErrorContains(t, err, "response header Content-Type has unexpected value")
+
+	// Headers are still validated
+	err = validate(http.StatusNoContent, http.Header{})
+	require.EqualError(t, err, `response header "X-Item-Id" missing`)
+
+	// Informational responses are not final
+	require.NoError(t, validate(http.StatusContinue, http.Header{}))
+	require.NoError(t, validate(http.StatusSwitchingProtocols, http.Header{}))
+
+	// unless they are handled strictly
+	validateStrictly := func(status int, header http.Header) error {
+		return ValidateResponse(context.Background(), &ResponseValidationInput{
+			RequestValidationInput: NewRequestValidationInput(req, route, pathParams),
+			Status:                 status,
+			Header:                 header,
+			Body:                   http.NoBody,
+			Options:                &Options{Strict1xxHandling: true},
+		})
+	}
+	require.NoError(t, validateStrictly(http.StatusContinue, http.Header{"X-Item-Id": {"1"}}))
+	err = validateStrictly(http.StatusContinue, http.Header{})
+	require.EqualError(t, err, `response header "X-Item-Id" missing`)
+	err = validateStrictly(http.StatusSwitchingProtocols, http.Header{"X-Item-Id": {"1"}})
+	require.EqualError(t, err, "informational status is not documented")
+}
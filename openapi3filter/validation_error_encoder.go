@@ -38,6 +38,11 @@ func ConvertErrors(err error) error {
 		cErr = convertErrInvalidRequired(e)
 	} else if e.Err == ErrInvalidEmptyValue {
 		cErr = convertErrInvalidEmptyValue(e)
+	} else if e.Err == ErrBodyTooLarge {
+		cErr = &ValidationError{
+			Status: http.StatusRequestEntityTooLarge,
+			Title:  e.Error(),
+		}
 	} else if innerErr, ok := e.Err.(*ParseError); ok {
 		cErr = convertParseError(e, innerErr)
 	} else if innerErr, ok := e.Err.(*openapi3.SchemaError); ok {
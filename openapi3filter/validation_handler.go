@@ -19,6 +19,20 @@ func NoopAuthenticationFunc(context.Context, *AuthenticationInput) error { retur
 
 var _ AuthenticationFunc = NoopAuthenticationFunc
 
+// NewScopeValidator returns an AuthenticationFunc that calls fn with the token sent with the request
+// and the scopes required for each security scheme of a security requirement.
+// ValidateSecurityRequirements requires all the schemes of a requirement to pass
+// and stops at the first passing requirement, so fn only checks a single scheme.
+// A non-nil error returned by fn fails authentication.
+func NewScopeValidator(fn func(ctx context.Context, token string, requiredScopes []string) error) AuthenticationFunc {
+	return func(ctx context.Context, input *AuthenticationInput) error {
+		if err := fn(ctx, input.Token, input.RequiredScopes()); err != nil {
+			return input.NewError(err)
+		}
+		return nil
+	}
+}
+
 type ValidationHandler struct {
 	Handler            http.Handler
 	AuthenticationFunc AuthenticationFunc
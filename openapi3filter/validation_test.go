@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
 	legacyrouter "github.com/getkin/kin-openapi/routers/legacy"
 )
 
@@ -249,14 +251,14 @@ func TestFilter(t *testing.T) {
 	// Test query parameter openapi3filter
 	req = ExampleRequest{
 		Method: "POST",
-		URL:    "http://example.com/api/prefix/v/suffix?queryArgAnyOf=ae&queryArgOneOf=ac&queryArgAllOf=2017-12-31T11:59:59",
+		URL:    "http://example.com/api/prefix/v/suffix?queryArgAnyOf=ae&queryArgOneOf=ac&queryArgAllOf=2017-12-31T11:59:59Z",
 	}
 	err = expect(req, resp)
 	require.NoError(t, err)
 
 	req = ExampleRequest{
 		Method: "POST",
-		URL:    "http://example.com/api/prefix/v/suffix?queryArgAnyOf=2017-12-31T11:59:59",
+		URL:    "http://example.com/api/prefix/v/suffix?queryArgAnyOf=2017-12-31T11:59:59Z",
 	}
 	err = expect(req, resp)
 	require.NoError(t, err)
@@ -270,7 +272,7 @@ func TestFilter(t *testing.T) {
 
 	req = ExampleRequest{
 		Method: "POST",
-		URL:    "http://example.com/api/prefix/v/suffix?queryArgOneOf=2017-12-31T11:59:59",
+		URL:    "http://example.com/api/prefix/v/suffix?queryArgOneOf=2017-12-31T11:59:59Z",
 	}
 	err = expect(req, resp)
 	require.IsType(t, &RequestError{}, err)
@@ -845,3 +847,77 @@ func makeAuthFunc(schemes map[string]bool) func(ctx context.Context, input *Auth
 		return fmt.Errorf("security scheme for %q is unknown", input.SecuritySchemeName)
 	}
 }
+
+func TestNewScopeValidator(t *testing.T) {
+	grants := map[string][]string{
+		"reader": {"pets:read"},
+		"writer": {"pets:read", "pets:write"},
+		"admin":  {"admin"},
+	}
+	authFunc := NewScopeValidator(func(ctx context.Context, token string, requiredScopes []string) error {
+		granted, ok := grants[token]
+		if !ok {
+			return errors.New("unknown token")
+		}
+	scopes:
+		for _, required := range requiredScopes {
+			for _, scope := range granted {
+				if scope == required {
+					continue scopes
+				}
+			}
+			return fmt.Errorf("missing scope %q", required)
+		}
+		return nil
+	})
+
+	route := &routers.Route{Spec: &openapi3.T{Components: &openapi3.Components{
+		SecuritySchemes: openapi3.SecuritySchemes{
+			"oauth": &openapi3.SecuritySchemeRef{Value: openapi3.NewOIDCSecurityScheme("https://example.com/.well-known/openid-configuration")},
+		},
+	}}}
+	// Either read and write access or admin access
+	srs := openapi3.SecurityRequirements{
+		{"oauth": {"pets:read", "pets:write"}},
+		{"oauth": {"admin"}},
+	}
+
+	for token, ok := range map[string]bool{
+		"reader":  false,
+		"writer":  true,
+		"admin":   true,
+		"unknown": false,
+	} {
+		httpReq := httptest.NewRequest(http.MethodGet, "/pets", nil)
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+		req := &RequestValidationInput{
+			Request: httpReq,
+			Route:   route,
+			Options: &Options{AuthenticationFunc: authFunc},
+		}
+		err := ValidateSecurityRequirements(context.Background(), req, srs)
+		if ok {
+			require.NoError(t, err, token)
+		} else {
+			require.Error(t, err, token)
+		}
+	}
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	httpReq.Header.Set("Authorization", "Bearer reader")
+	req := &RequestValidationInput{Request: httpReq, Route: route, Options: &Options{AuthenticationFunc: authFunc}}
+	err := ValidateSecurityRequirements(context.Background(), req, srs[:1])
+	require.EqualError(t, err, `security requirements failed: authorization failed: missing scope "pets:write"`)
+
+	// Scopes only apply to oauth2 and openIdConnect schemes
+	for scheme, scopes := range map[*openapi3.SecurityScheme][]string{
+		openapi3.NewOIDCSecurityScheme("https://example.com/.well-known/openid-configuration"): {"admin"},
+		{Type: "oauth2"}:                 {"admin"},
+		openapi3.NewJWTSecurityScheme():  nil,
+		openapi3.NewCSRFSecurityScheme(): nil,
+	} {
+		input := &AuthenticationInput{SecurityScheme: scheme, Scopes: []string{"admin"}}
+		require.Equal(t, scopes, input.RequiredScopes(), scheme.Type)
+	}
+	require.Nil(t, (&AuthenticationInput{Scopes: []string{"admin"}}).RequiredScopes())
+}
@@ -0,0 +1,159 @@
+package openapi3filter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// xmlBodyDecoder decodes a flat XML document into an object,
+// converting its elements according to the types of the schema properties.
+func xmlBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn openapi3filter.EncodingFn) (interface{}, error) {
+	var doc struct {
+		Fields []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	}
+	if err := xml.NewDecoder(body).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	value := make(map[string]interface{}, len(doc.Fields))
+	for _, field := range doc.Fields {
+		name := field.XMLName.Local
+		value[name] = field.Value
+		if prop := schema.Value.Properties[name]; prop != nil && prop.Value.Type.Is(openapi3.TypeInteger) {
+			n, err := strconv.ParseFloat(field.Value, 64)
+			if err != nil {
+				return nil, err
+			}
+			value[name] = n
+		}
+	}
+	return value, nil
+}
+
+// xmlBodyEncoder encodes an object into a flat XML document, with sorted elements.
+func xmlBodyEncoder(body interface{}) ([]byte, error) {
+	value := body.(map[string]interface{})
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("<pet>")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "<%s>%v</%s>", name, value[name], name)
+	}
+	buf.WriteString("</pet>")
+	return buf.Bytes(), nil
+}
+
+func Example_validateXMLBody() {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          text/xml:
+            schema:
+              type: object
+              required:
+                - name
+              properties:
+                name:
+                  type: string
+                age:
+                  type: integer
+                  minimum: 0
+                  default: 1
+      responses:
+        '201':
+          description: Created
+`
+
+	openapi3filter.RegisterBodyDecoder("text/xml", xmlBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("text/xml")
+	// The encoder writes the body back once default values are set
+	openapi3filter.RegisterBodyEncoder("text/xml", xmlBodyEncoder)
+	defer openapi3filter.UnregisterBodyEncoder("text/xml")
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	if err != nil {
+		panic(err)
+	}
+	if err = doc.Validate(loader.Context); err != nil {
+		panic(err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, body := range []string{
+		`<pet><name>Rex</name><age>3</age></pet>`,
+		`<pet><name>Rex</name><age>-3</age></pet>`,
+		`<pet><name>Rex</name></pet>`,
+	} {
+		req, err := http.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		if err != nil {
+			panic(err)
+		}
+		req.Header.Set("Content-Type", "text/xml")
+
+		route, pathParams, err := router.FindRoute(req)
+		if err != nil {
+			panic(err)
+		}
+
+		err = openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		})
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(data))
+	}
+	// Output:
+	// <pet><name>Rex</name><age>3</age></pet>
+	// request body has an error: doesn't match schema: Error at "/age": number must be at least 0
+	// Schema:
+	//   {
+	//     "default": 1,
+	//     "minimum": 0,
+	//     "type": "integer"
+	//   }
+	//
+	// Value:
+	//   -3
+	//
+	// <pet><age>1</age><name>Rex</name></pet>
+}
@@ -108,6 +108,23 @@ func (x *${type}Ref) Validate(ctx context.Context, opts ...ValidationOption) err
 	if v := x.Value; v != nil {
 		return v.Validate(ctx)
 	}
+	if resolve := getValidationOptions(ctx).refResolver; resolve != nil {
+		resolved, err := resolve(x.Ref)
+		if err != nil {
+			return err
+		}
+		switch v := resolved.(type) {
+		case nil:
+			return nil
+		case *${type}:
+			if v == nil {
+				return nil
+			}
+			return v.Validate(ctx)
+		default:
+			return fmt.Errorf("ref %q resolved to unexpected %T", x.Ref, resolved)
+		}
+	}
 	return foundUnresolvedRef(x.Ref)
 }
 
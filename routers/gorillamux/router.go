@@ -21,6 +21,12 @@ import (
 
 var _ routers.Router = &Router{}
 
+func init() {
+	routers.RegisterBackend(routers.BackendGorillaMux, func(doc *openapi3.T, options routers.Options) (routers.Router, error) {
+		return NewRouter(doc)
+	})
+}
+
 // Router helps link http.Request.s and an OpenAPIv3 spec
 type Router struct {
 	muxes  []routeMux
@@ -58,8 +64,9 @@ func NewRouter(doc *openapi3.T) (routers.Router, error) {
 	r := &Router{}
 	for _, path := range doc.Paths.InMatchingOrder() {
 		pathItem := doc.Paths[path]
+		pathServers := servers
 		if len(pathItem.Servers) > 0 {
-			if servers, err = makeServers(pathItem.Servers); err != nil {
+			if pathServers, err = makeServers(pathItem.Servers); err != nil {
 				return nil, err
 			}
 		}
@@ -71,7 +78,7 @@ func NewRouter(doc *openapi3.T) (routers.Router, error) {
 		}
 		sort.Strings(methods)
 
-		for _, s := range servers {
+		for _, s := range pathServers {
 			muxRoute := muxRouter.Path(s.base + path).Methods(methods...)
 			if schemes := s.schemes; len(schemes) != 0 {
 				muxRoute.Schemes(schemes...)
@@ -126,6 +133,15 @@ func (r *Router) FindRoute(req *http.Request) (*routers.Route, map[string]string
 	return nil, nil, routers.ErrPathNotFound
 }
 
+// FindRouteByMethodAndPath extracts the route and parameters of a method and path
+func (r *Router) FindRouteByMethodAndPath(method, path string) (*routers.Route, map[string]string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.FindRoute(&http.Request{Method: method, URL: u, Host: u.Host})
+}
+
 func makeServers(in openapi3.Servers) ([]srv, error) {
 	servers := make([]srv, 0, len(in))
 	for _, server := range in {
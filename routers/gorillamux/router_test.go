@@ -2,6 +2,7 @@ package gorillamux
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"sort"
 	"testing"
@@ -129,7 +130,7 @@ func TestRouter(t *testing.T) {
 		}
 	}
 
-	err := doc.Validate(context.Background())
+	err := doc.Validate(context.Background(), openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
 	r, err := NewRouter(doc)
 	require.NoError(t, err)
@@ -170,7 +171,7 @@ func TestRouter(t *testing.T) {
 			"port": {Default: "8000"},
 		}},
 	}
-	err = doc.Validate(context.Background())
+	err = doc.Validate(context.Background(), openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
 	r, err = NewRouter(doc)
 	require.NoError(t, err)
@@ -194,7 +195,7 @@ func TestRouter(t *testing.T) {
 			"server": {Default: "/api/v1"},
 		}},
 	}
-	err = doc.Validate(context.Background())
+	err = doc.Validate(context.Background(), openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
 	r, err = NewRouter(doc)
 	require.NoError(t, err)
@@ -305,6 +306,56 @@ func TestServerOverrideAtPathLevel(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestMultipleServersAtPathLevel(t *testing.T) {
+	helloGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	byeGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "multi-region",
+			Version: "1",
+		},
+		Servers: openapi3.Servers{
+			&openapi3.Server{URL: "https://example.com"},
+		},
+		Paths: openapi3.Paths{
+			"/hello": &openapi3.PathItem{
+				Servers: openapi3.Servers{
+					&openapi3.Server{URL: "https://eu.example.com/v1"},
+					&openapi3.Server{URL: "https://us.example.com/api/v2"},
+				},
+				Get: helloGET,
+			},
+			"/bye": &openapi3.PathItem{
+				Get: byeGET,
+			},
+		},
+	}
+	err := doc.Validate(context.Background())
+	require.NoError(t, err)
+	router, err := NewRouter(doc)
+	require.NoError(t, err)
+
+	for uri, operation := range map[string]*openapi3.Operation{
+		"https://eu.example.com/v1/hello":     helloGET,
+		"https://us.example.com/api/v2/hello": helloGET,
+		"https://example.com/hello":           nil,
+		"https://us.example.com/v1/hello":     nil,
+		"https://example.com/bye":             byeGET,
+		"https://eu.example.com/v1/bye":       nil,
+	} {
+		req, err := http.NewRequest(http.MethodGet, uri, nil)
+		require.NoError(t, err)
+		route, _, err := router.FindRoute(req)
+		if operation == nil {
+			require.Error(t, err, uri)
+			continue
+		}
+		require.NoError(t, err, uri)
+		require.Same(t, operation, route.Operation, uri)
+	}
+}
+
 func TestRelativeURL(t *testing.T) {
 	helloGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
 	doc := &openapi3.T{
@@ -507,3 +558,82 @@ func newServerVariable(defaultValue string) *openapi3.ServerVariable {
 		Description: "",
 	}
 }
+
+func TestFindRouteByMethodAndPath(t *testing.T) {
+	getPet := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Paths: openapi3.Paths{
+			"/pets/{petId}": &openapi3.PathItem{
+				Get: getPet,
+				Parameters: openapi3.Parameters{
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("petId")},
+				},
+			},
+		},
+	}
+	router, err := NewRouter(doc)
+	require.NoError(t, err)
+
+	route, pathParams, err := router.FindRouteByMethodAndPath(http.MethodGet, "/pets/42")
+	require.NoError(t, err)
+	require.Equal(t, getPet, route.Operation)
+	require.Equal(t, map[string]string{"petId": "42"}, pathParams)
+
+	_, _, err = router.FindRouteByMethodAndPath(http.MethodPost, "/pets/42")
+	require.EqualError(t, err, routers.ErrMethodNotAllowed.Error())
+
+	_, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "/owners/42")
+	require.EqualError(t, err, routers.ErrPathNotFound.Error())
+}
+
+func TestNewRouterInvalidPathTemplate(t *testing.T) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Paths: openapi3.Paths{
+			"/pets/{petId": &openapi3.PathItem{
+				Get: &openapi3.Operation{Responses: openapi3.NewResponses()},
+			},
+		},
+	}
+	router, err := NewRouter(doc)
+	require.EqualError(t, err, `mux: unbalanced braces in "/pets/{petId"`)
+	require.Nil(t, router)
+}
+
+// BenchmarkFirstRequest measures the latency of the first match of a new router:
+// path templates are compiled by NewRouter, not when matching.
+func BenchmarkFirstRequest(b *testing.B) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Paths: make(openapi3.Paths),
+	}
+	for i := 0; i < 100; i++ {
+		require.NoError(b, doc.AddOperation(fmt.Sprintf("/resources%d/{id}", i), http.MethodGet, &openapi3.Operation{Responses: openapi3.NewResponses()}))
+	}
+	req, err := http.NewRequest(http.MethodGet, "/resources99/42", nil)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		router, err := NewRouter(doc)
+		require.NoError(b, err)
+		b.StartTimer()
+
+		_, _, err = router.FindRoute(req)
+		require.NoError(b, err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -18,6 +19,12 @@ import (
 	"github.com/getkin/kin-openapi/routers/legacy/pathpattern"
 )
 
+func init() {
+	routers.RegisterBackend(routers.BackendLegacy, func(doc *openapi3.T, options routers.Options) (routers.Router, error) {
+		return NewRouter(doc)
+	})
+}
+
 // Routers maps a HTTP request to a Router.
 type Routers []*Router
 
@@ -105,45 +112,59 @@ func (router *Router) node() *pathpattern.Node {
 }
 
 // FindRoute extracts the route and parameters of an http.Request
+//
+// When several servers match the request URL, the route is looked up with each of them in order
+// and the first route found is returned.
 func (router *Router) FindRoute(req *http.Request) (*routers.Route, map[string]string, error) {
 	method, url := req.Method, req.URL
-	doc := router.doc
 
 	// Get server
-	servers := doc.Servers
-	var server *openapi3.Server
-	var remainingPath string
-	var pathParams map[string]string
+	servers := router.doc.Servers
 	if len(servers) == 0 {
-		remainingPath = url.Path
-	} else {
-		var paramValues []string
-		server, paramValues, remainingPath = servers.MatchURL(url)
-		if server == nil {
-			return nil, nil, &routers.RouteError{
-				Reason: routers.ErrPathNotFound.Error(),
-			}
+		return router.findRoute(method, url.Path, nil, nil)
+	}
+	var err error = &routers.RouteError{Reason: routers.ErrPathNotFound.Error()}
+	for _, server := range servers {
+		matched, paramValues, remainingPath := openapi3.Servers{server}.MatchURL(url)
+		if matched == nil {
+			continue
 		}
-		pathParams = make(map[string]string)
-		paramNames, err := server.ParameterNames()
-		if err != nil {
-			return nil, nil, err
+		pathParams := make(map[string]string)
+		paramNames, namesErr := server.ParameterNames()
+		if namesErr != nil {
+			return nil, nil, namesErr
 		}
 		for i, value := range paramValues {
 			name := paramNames[i]
 			pathParams[name] = value
 		}
+
+		route, pathParams, serverErr := router.findRoute(method, remainingPath, server, pathParams)
+		if serverErr == nil {
+			return route, pathParams, nil
+		}
+		var routeErr *routers.RouteError
+		if !errors.As(serverErr, &routeErr) || routeErr.Reason != routers.ErrPathNotFound.Error() {
+			// e.g. method not allowed
+			err = serverErr
+		}
 	}
+	return nil, nil, err
+}
+
+// findRoute matches method and path, the part of the request path that follows the server's base path, if any.
+func (router *Router) findRoute(method, path string, server *openapi3.Server, pathParams map[string]string) (*routers.Route, map[string]string, error) {
+	doc := router.doc
 
 	// Get PathItem
 	root := router.node()
 	var route *routers.Route
-	node, paramValues := root.Match(method + " " + remainingPath)
+	node, paramValues := root.Match(method + " " + path)
 	if node != nil {
 		route, _ = node.Value.(*routers.Route)
 	}
 	if route == nil {
-		pathItem := doc.Paths[remainingPath]
+		pathItem := doc.Paths[path]
 		if pathItem == nil {
 			return nil, nil, &routers.RouteError{Reason: routers.ErrPathNotFound.Error()}
 		}
@@ -152,13 +173,30 @@ func (router *Router) FindRoute(req *http.Request) (*routers.Route, map[string]s
 		}
 	}
 
+	if route != nil && server != nil {
+		matched := *route
+		matched.Server = server
+		route = &matched
+	}
+
 	if pathParams == nil {
 		pathParams = make(map[string]string, len(paramValues))
 	}
-	paramKeys := node.VariableNames
-	for i, value := range paramValues {
-		key := strings.TrimSuffix(paramKeys[i], "*")
-		pathParams[key] = value
+	if node != nil {
+		paramKeys := node.VariableNames
+		for i, value := range paramValues {
+			key := strings.TrimSuffix(paramKeys[i], "*")
+			pathParams[key] = value
+		}
 	}
 	return route, pathParams, nil
 }
+
+// FindRouteByMethodAndPath extracts the route and parameters of a method and path
+func (router *Router) FindRouteByMethodAndPath(method, path string) (*routers.Route, map[string]string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return router.FindRoute(&http.Request{Method: method, URL: u, Host: u.Host})
+}
@@ -127,9 +127,9 @@ func TestRouter(t *testing.T) {
 		}
 	}
 
-	err := doc.Validate(context.Background())
+	err := doc.Validate(context.Background(), openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
-	r, err := NewRouter(doc)
+	r, err := NewRouter(doc, openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
 
 	expect(r, http.MethodGet, "/not_existing", nil, nil)
@@ -167,9 +167,9 @@ func TestRouter(t *testing.T) {
 			"d1": {Default: "example", Enum: []string{"example"}},
 		}},
 	}
-	err = doc.Validate(context.Background())
+	err = doc.Validate(context.Background(), openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
-	r, err = NewRouter(doc)
+	r, err = NewRouter(doc, openapi3.WithAllowNonStandardPaths())
 	require.NoError(t, err)
 	expect(r, http.MethodGet, "/hello", nil, nil)
 	expect(r, http.MethodGet, "/api/v1/hello", nil, nil)
@@ -195,7 +195,7 @@ func TestRouter(t *testing.T) {
 	}
 
 	schema := &openapi3.Schema{
-		Type:    "string",
+		Type:    openapi3.SchemaTypes{"string"},
 		Example: 3,
 	}
 	content := openapi3.NewContentWithJSONSchema(schema)
@@ -204,10 +204,109 @@ func TestRouter(t *testing.T) {
 	doc.Paths["/withExamples"] = &openapi3.PathItem{
 		Get: &openapi3.Operation{Responses: responses},
 	}
-	err = doc.Validate(context.Background())
+	err = doc.Validate(context.Background(), openapi3.WithAllowNonStandardPaths())
 	require.Error(t, err)
-	r, err = NewRouter(doc)
+	r, err = NewRouter(doc, openapi3.WithAllowNonStandardPaths())
 	require.Error(t, err)
-	r, err = NewRouter(doc, openapi3.DisableExamplesValidation())
+	r, err = NewRouter(doc, openapi3.WithAllowNonStandardPaths(), openapi3.DisableExamplesValidation())
 	require.NoError(t, err)
 }
+
+func TestFindRouteByMethodAndPath(t *testing.T) {
+	getPet := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Paths: openapi3.Paths{
+			"/pets/{petId}": &openapi3.PathItem{
+				Get: getPet,
+				Parameters: openapi3.Parameters{
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("petId")},
+				},
+			},
+		},
+	}
+	router, err := NewRouter(doc)
+	require.NoError(t, err)
+
+	route, pathParams, err := router.FindRouteByMethodAndPath(http.MethodGet, "/pets/42")
+	require.NoError(t, err)
+	require.Equal(t, getPet, route.Operation)
+	require.Equal(t, map[string]string{"petId": "42"}, pathParams)
+
+	_, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "/owners/42")
+	require.EqualError(t, err, routers.ErrPathNotFound.Error())
+}
+
+func TestRouterParamsWithUnderscores(t *testing.T) {
+	getUser := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Paths: openapi3.Paths{
+			"/users/{user_id}/{_id}/{id_2}": &openapi3.PathItem{
+				Get: getUser,
+				Parameters: openapi3.Parameters{
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("user_id")},
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("_id")},
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("id_2")},
+				},
+			},
+		},
+	}
+	router, err := NewRouter(doc)
+	require.NoError(t, err)
+
+	route, pathParams, err := router.FindRouteByMethodAndPath(http.MethodGet, "/users/alice/b_1/c-2")
+	require.NoError(t, err)
+	require.Equal(t, getUser, route.Operation)
+	require.Equal(t, map[string]string{"user_id": "alice", "_id": "b_1", "id_2": "c-2"}, pathParams)
+}
+
+func TestRouterTriesEachMatchingServer(t *testing.T) {
+	getPet := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Servers: openapi3.Servers{
+			{URL: "https://example.com"},
+			{URL: "https://example.com/{region}", Variables: map[string]*openapi3.ServerVariable{
+				"region": {Default: "eu", Enum: []string{"eu", "us"}},
+			}},
+		},
+		Paths: openapi3.Paths{
+			"/pets/{petId}": &openapi3.PathItem{
+				Get: getPet,
+				Parameters: openapi3.Parameters{
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("petId")},
+				},
+			},
+		},
+	}
+	router, err := NewRouter(doc)
+	require.NoError(t, err)
+
+	route, pathParams, err := router.FindRouteByMethodAndPath(http.MethodGet, "https://example.com/pets/42")
+	require.NoError(t, err)
+	require.Equal(t, getPet, route.Operation)
+	require.Same(t, doc.Servers[0], route.Server)
+	require.Equal(t, map[string]string{"petId": "42"}, pathParams)
+
+	route, pathParams, err = router.FindRouteByMethodAndPath(http.MethodGet, "https://example.com/us/pets/42")
+	require.NoError(t, err)
+	require.Equal(t, getPet, route.Operation)
+	require.Same(t, doc.Servers[1], route.Server)
+	require.Equal(t, map[string]string{"region": "us", "petId": "42"}, pathParams)
+
+	_, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "https://example.com/us/owners/42")
+	require.EqualError(t, err, routers.ErrPathNotFound.Error())
+}
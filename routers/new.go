@@ -0,0 +1,162 @@
+package routers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Backend names a Router implementation.
+type Backend string
+
+// Backends provided by this module
+const (
+	BackendGorillaMux = Backend("gorillamux")
+	BackendLegacy     = Backend("legacy")
+)
+
+// DefaultBackend is the backend used by New when none is given.
+const DefaultBackend = BackendGorillaMux
+
+// RouterOption allows the modification of how a Router is created by New.
+type RouterOption func(*Options)
+
+// Options describe how a Router is created by New.
+type Options struct {
+	Backend Backend
+
+	// StrictServerMatching makes requests that do not match any of the document's servers fail.
+	// When disabled, such requests are matched against the paths as if the document had no servers.
+	StrictServerMatching bool
+
+	// ServerBasePathStripping makes the router strip the path of the first matching server
+	// from request paths before matching them against the document's paths.
+	// When disabled, servers (including the ones overridden at the path level) are ignored
+	// and request paths are matched against the document's paths as is.
+	ServerBasePathStripping bool
+}
+
+// WithBackend selects the Router implementation.
+func WithBackend(backend Backend) RouterOption {
+	return func(options *Options) {
+		options.Backend = backend
+	}
+}
+
+// WithStrictServerMatching enables or disables matching requests with the document's servers.
+// It is enabled by default.
+func WithStrictServerMatching(strict bool) RouterOption {
+	return func(options *Options) {
+		options.StrictServerMatching = strict
+	}
+}
+
+// WithServerBasePathStripping enables or disables stripping the servers' base path
+// (e.g. "/api/v1") from request paths. It is enabled by default.
+func WithServerBasePathStripping(enabled bool) RouterOption {
+	return func(options *Options) {
+		options.ServerBasePathStripping = enabled
+	}
+}
+
+// NewRouterFunc creates a Router for a document.
+type NewRouterFunc func(doc *openapi3.T, options Options) (Router, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[Backend]NewRouterFunc)
+)
+
+// RegisterBackend makes a Router implementation available to New.
+// It is called by the backend packages (e.g. routers/gorillamux) when imported.
+func RegisterBackend(backend Backend, newRouter NewRouterFunc) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[backend] = newRouter
+}
+
+// New creates a Router for the document using the backend selected by the options,
+// which defaults to DefaultBackend.
+//
+// Backends register themselves when their package is imported, e.g.:
+//
+//	import _ "github.com/getkin/kin-openapi/routers/gorillamux"
+func New(doc *openapi3.T, opts ...RouterOption) (Router, error) {
+	options := Options{
+		Backend:                 DefaultBackend,
+		StrictServerMatching:    true,
+		ServerBasePathStripping: true,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if !options.ServerBasePathStripping {
+		doc = withoutServers(doc)
+	}
+
+	backendsMu.RLock()
+	newRouter, ok := backends[options.Backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown router backend %q (forgotten import?)", options.Backend)
+	}
+
+	router, err := newRouter(doc, options)
+	if err != nil {
+		return nil, err
+	}
+	if options.StrictServerMatching || len(doc.Servers) == 0 {
+		return router, nil
+	}
+
+	fallback, err := newRouter(withoutServers(doc), options)
+	if err != nil {
+		return nil, err
+	}
+	return &fallbackRouter{router: router, fallback: fallback}, nil
+}
+
+// withoutServers returns a shallow copy of doc without any servers.
+func withoutServers(doc *openapi3.T) *openapi3.T {
+	serverless := *doc
+	serverless.Servers = nil
+	serverless.Paths = make(openapi3.Paths, len(doc.Paths))
+	for path, pathItem := range doc.Paths {
+		if pathItem != nil && len(pathItem.Servers) != 0 {
+			item := *pathItem
+			item.Servers = nil
+			pathItem = &item
+		}
+		serverless.Paths[path] = pathItem
+	}
+	return &serverless
+}
+
+// fallbackRouter matches routes with fallback when router finds no path.
+type fallbackRouter struct {
+	router, fallback Router
+}
+
+func (r *fallbackRouter) FindRoute(req *http.Request) (*Route, map[string]string, error) {
+	route, pathParams, err := r.router.FindRoute(req)
+	if isPathNotFound(err) {
+		return r.fallback.FindRoute(req)
+	}
+	return route, pathParams, err
+}
+
+func (r *fallbackRouter) FindRouteByMethodAndPath(method, path string) (*Route, map[string]string, error) {
+	route, pathParams, err := r.router.FindRouteByMethodAndPath(method, path)
+	if isPathNotFound(err) {
+		return r.fallback.FindRouteByMethodAndPath(method, path)
+	}
+	return route, pathParams, err
+}
+
+func isPathNotFound(err error) bool {
+	var routeErr *RouteError
+	return errors.As(err, &routeErr) && routeErr.Reason == ErrPathNotFound.Error()
+}
@@ -0,0 +1,104 @@
+package routers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+func TestNew(t *testing.T) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Servers: openapi3.Servers{
+			&openapi3.Server{URL: "https://api.example.com/v1"},
+		},
+		Paths: openapi3.Paths{
+			"/hello": &openapi3.PathItem{
+				Get: &openapi3.Operation{Responses: openapi3.NewResponses()},
+			},
+		},
+	}
+
+	router, err := routers.New(doc)
+	require.NoError(t, err)
+	require.IsType(t, &gorillamux.Router{}, router)
+
+	router, err = routers.New(doc, routers.WithBackend(routers.BackendLegacy))
+	require.NoError(t, err)
+	require.IsType(t, &legacy.Router{}, router)
+
+	_, err = routers.New(doc, routers.WithBackend("unknown"))
+	require.EqualError(t, err, `unknown router backend "unknown" (forgotten import?)`)
+
+	for _, backend := range []routers.Backend{routers.BackendGorillaMux, routers.BackendLegacy} {
+		t.Run(string(backend), func(t *testing.T) {
+			router, err := routers.New(doc, routers.WithBackend(backend))
+			require.NoError(t, err)
+			route, _, err := router.FindRouteByMethodAndPath(http.MethodGet, "https://api.example.com/v1/hello")
+			require.NoError(t, err)
+			require.Equal(t, "/hello", route.Path)
+			_, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "/hello")
+			require.EqualError(t, err, routers.ErrPathNotFound.Error())
+
+			router, err = routers.New(doc, routers.WithBackend(backend), routers.WithStrictServerMatching(false))
+			require.NoError(t, err)
+			route, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "https://api.example.com/v1/hello")
+			require.NoError(t, err)
+			require.Equal(t, "/hello", route.Path)
+			route, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "/hello")
+			require.NoError(t, err)
+			require.Equal(t, "/hello", route.Path)
+			require.Nil(t, route.Server)
+		})
+	}
+}
+
+func TestNewWithServerBasePathStripping(t *testing.T) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "MyAPI",
+			Version: "0.1",
+		},
+		Servers: openapi3.Servers{
+			&openapi3.Server{URL: "/api/v2"},
+			&openapi3.Server{URL: "/api/v1"},
+		},
+		Paths: openapi3.Paths{
+			"/hello": &openapi3.PathItem{
+				Get: &openapi3.Operation{Responses: openapi3.NewResponses()},
+			},
+		},
+	}
+
+	for _, backend := range []routers.Backend{routers.BackendGorillaMux, routers.BackendLegacy} {
+		t.Run(string(backend), func(t *testing.T) {
+			router, err := routers.New(doc, routers.WithBackend(backend))
+			require.NoError(t, err)
+			for _, path := range []string{"/api/v1/hello", "https://example.com/api/v1/hello"} {
+				route, _, err := router.FindRouteByMethodAndPath(http.MethodGet, path)
+				require.NoError(t, err)
+				require.Equal(t, "/hello", route.Path)
+				require.Equal(t, doc.Servers[1], route.Server)
+			}
+
+			router, err = routers.New(doc, routers.WithBackend(backend), routers.WithServerBasePathStripping(false))
+			require.NoError(t, err)
+			route, _, err := router.FindRouteByMethodAndPath(http.MethodGet, "/hello")
+			require.NoError(t, err)
+			require.Equal(t, "/hello", route.Path)
+			_, _, err = router.FindRouteByMethodAndPath(http.MethodGet, "/api/v1/hello")
+			require.EqualError(t, err, routers.ErrPathNotFound.Error())
+		})
+	}
+}
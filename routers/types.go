@@ -16,6 +16,11 @@ type Router interface {
 	//
 	// See openapi3filter for example uses with request and response validation.
 	FindRoute(req *http.Request) (route *Route, pathParams map[string]string, err error)
+
+	// FindRouteByMethodAndPath matches an HTTP method and a path with the operation they resolve to,
+	// without requiring an http.Request.
+	// The path may be an absolute URL in order to match servers with a host.
+	FindRouteByMethodAndPath(method, path string) (route *Route, pathParams map[string]string, err error)
 }
 
 // Route describes the operation an http.Request can match
@@ -28,6 +33,21 @@ type Route struct {
 	Operation *openapi3.Operation
 }
 
+// EffectiveServers returns the servers applicable to the route:
+// the operation's servers if set, otherwise the path item's, otherwise the document's.
+func (route *Route) EffectiveServers() openapi3.Servers {
+	if operation := route.Operation; operation != nil && operation.Servers != nil && len(*operation.Servers) != 0 {
+		return *operation.Servers
+	}
+	if pathItem := route.PathItem; pathItem != nil && len(pathItem.Servers) != 0 {
+		return pathItem.Servers
+	}
+	if route.Spec != nil {
+		return route.Spec.Servers
+	}
+	return nil
+}
+
 // ErrPathNotFound is returned when no route match is found
 var ErrPathNotFound error = &RouteError{"no matching operation was found"}
 
@@ -0,0 +1,30 @@
+package routers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestRouteEffectiveServers(t *testing.T) {
+	docServers := openapi3.Servers{&openapi3.Server{URL: "https://example.com/v1"}}
+	pathServers := openapi3.Servers{&openapi3.Server{URL: "https://path.example.com"}}
+	operationServers := openapi3.Servers{&openapi3.Server{URL: "https://operation.example.com"}}
+
+	route := &Route{
+		Spec:      &openapi3.T{Servers: docServers},
+		PathItem:  &openapi3.PathItem{},
+		Operation: &openapi3.Operation{},
+	}
+	require.Equal(t, docServers, route.EffectiveServers())
+
+	route.PathItem.Servers = pathServers
+	require.Equal(t, pathServers, route.EffectiveServers())
+
+	route.Operation.Servers = &operationServers
+	require.Equal(t, operationServers, route.EffectiveServers())
+
+	require.Nil(t, (&Route{}).EffectiveServers())
+}